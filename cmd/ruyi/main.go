@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -22,8 +23,14 @@ type Config struct {
 	Out    string
 	Params ParamMap
 	Help   bool
+	JSON   bool
+	Matrix bool
+	Strict bool
 }
 
+// autoFrom 表示根据输入内容自动探测源 Concept
+const autoFrom = "auto"
+
 // Handler 定义转换处理逻辑的接口
 type Handler interface {
 	Handle(ctx context.Context, r contract.Ruyi, cfg *Config) error
@@ -50,11 +57,16 @@ func run() error {
 	}
 
 	// 2. 创建 Ruyi 实例
-	r, err := ruyi.New()
+	r, err := ruyi.New(ruyi.WithStrictParams(cfg.Strict))
 	if err != nil {
 		return fmt.Errorf("创建 Ruyi 实例失败: %w", err)
 	}
 
+	// 如果是 Matrix 模式，只打印支持矩阵并退出
+	if cfg.Matrix {
+		return printMatrix(r, contract.Kind(strings.ToLower(cfg.Kind)))
+	}
+
 	// 3. 获取对应 Kind 的 Handler
 	handler, err := getHandler(cfg.Kind)
 	if err != nil {
@@ -69,15 +81,29 @@ func run() error {
 func parseFlags() (*Config, error) {
 	cfg := &Config{}
 	flag.StringVar(&cfg.Kind, "kind", "", "转换类型 (file)")
-	flag.StringVar(&cfg.From, "from", "", "源 Concept 格式 (例如 png, usd, yyyy-mm-dd)")
+	flag.StringVar(&cfg.From, "from", "", "源 Concept 格式 (例如 png, usd, yyyy-mm-dd)，auto 表示根据输入内容自动探测")
 	flag.StringVar(&cfg.To, "to", "", "目标 Concept 格式 (例如 jpeg, cny, timestamp)")
 	flag.StringVar(&cfg.In, "in", "", "输入内容: 文件路径 或 原始数据")
 	flag.StringVar(&cfg.Out, "out", "", "输出内容: 文件路径 或 原始数据输出路径")
 	flag.Var(&cfg.Params, "param", "转换器参数（key=value 或 key=value;key=value）可多次指定，或使用分号分隔")
 	flag.BoolVar(&cfg.Help, "help", false, "显示帮助信息")
+	flag.BoolVar(&cfg.JSON, "json", false, "与 --help 一起使用，以 JSON Schema 格式输出转换器参数")
+	flag.BoolVar(&cfg.Matrix, "matrix", false, "以 Markdown 表格输出指定 kind 的转换支持矩阵")
+	flag.BoolVar(&cfg.Strict, "strict", false, "严格参数模式：拒绝未声明的参数（例如拼写错误），默认关闭，与 ruyi.WithStrictParams 一致")
 
 	flag.Parse()
 
+	// 如果指定了 matrix，只需要 kind 参数即可
+	if cfg.Matrix {
+		if cfg.Kind == "" {
+			fmt.Println("使用 --matrix 输出支持矩阵时，必须提供 kind 参数")
+			fmt.Println("示例: go run cmd/ruyi/main.go -kind file --matrix")
+			flag.Usage()
+			return nil, fmt.Errorf("查询参数缺失")
+		}
+		return cfg, nil
+	}
+
 	// 如果指定了 help，只需要必要的参数即可
 	if cfg.Help {
 		if cfg.Kind == "" || cfg.From == "" || cfg.To == "" {
@@ -116,6 +142,25 @@ func (h *FileHandler) Handle(ctx context.Context, r contract.Ruyi, cfg *Config)
 	toName := contract.ConceptName(cfg.To)
 	kind := contract.File
 
+	// 自动探测源格式
+	var fromData []byte
+	if strings.EqualFold(cfg.From, autoFrom) {
+		if cfg.Help {
+			return fmt.Errorf("使用 --help 查询参数时，必须显式指定 from")
+		}
+
+		data, err := os.ReadFile(cfg.In)
+		if err != nil {
+			return fmt.Errorf("读取输入文件失败: %w", err)
+		}
+		concept, ok := contract.DetectConcept(data)
+		if !ok || concept.Kind() != kind {
+			return fmt.Errorf("无法识别输入文件的格式: %s", cfg.In)
+		}
+		fromData, fromName = data, concept.Name()
+		fmt.Printf("探测到源格式: %s\n", fromName)
+	}
+
 	// 获取 Converter
 	converter, err := r.GetConverter(ctx, kind, fromName, toName)
 	if err != nil {
@@ -127,6 +172,9 @@ func (h *FileHandler) Handle(ctx context.Context, r contract.Ruyi, cfg *Config)
 
 	// 如果是 Help 模式，只打印参数信息并退出
 	if cfg.Help {
+		if cfg.JSON {
+			return printParamsJSONSchema(converter.Params())
+		}
 		printParams(converter.Params())
 		return nil
 	}
@@ -135,14 +183,19 @@ func (h *FileHandler) Handle(ctx context.Context, r contract.Ruyi, cfg *Config)
 	printParams(converter.Params())
 
 	// 读取输入文件
-	fromData, err := os.ReadFile(cfg.In)
-	if err != nil {
-		return fmt.Errorf("读取输入文件失败: %w", err)
+	if fromData == nil {
+		fromData, err = os.ReadFile(cfg.In)
+		if err != nil {
+			return fmt.Errorf("读取输入文件失败: %w", err)
+		}
 	}
 
 	// 执行转换
-	outData, err := converter.Convert(ctx, fromData, cfg.Params)
+	outData, err := r.Convert(ctx, kind, fromName, toName, fromData, cfg.Params)
 	if err != nil {
+		if paramErrs := contract.ParamErrors(err); len(paramErrs) > 0 {
+			return formatParamErrors(paramErrs)
+		}
 		return fmt.Errorf("文件转换失败: %w", err)
 	}
 
@@ -167,7 +220,7 @@ func printParams(params []contract.ConverterParam) {
 	if len(params) == 0 {
 		sb.WriteString("  (无参数)\n")
 	} else {
-		for _, p := range params {
+		for _, p := range contract.SortParams(params) {
 			required := "否"
 			if p.Required {
 				required = "是"
@@ -179,14 +232,99 @@ func printParams(params []contract.ConverterParam) {
 			}
 
 			sb.WriteString(fmt.Sprintf(
-				"  - %s\n    描述: %s\n    默认值: %q\n    必填: %s%s\n",
-				p.Name, p.Desc, p.Default, required, checkDesc,
+				"  - %s\n    描述: %s\n    类型: %s\n    默认值: %q\n    必填: %s%s\n",
+				p.Name, p.Desc, p.Schema, p.Default, required, checkDesc,
 			))
 		}
 	}
 	fmt.Println(sb.String())
 }
 
+// formatParamErrors 将参数错误汇总为一条易读的错误信息
+func formatParamErrors(paramErrs []*contract.ParamError) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("参数错误 (共 %d 个):", len(paramErrs)))
+	for _, e := range paramErrs {
+		switch e.Reason {
+		case contract.ParamMissing:
+			sb.WriteString(fmt.Sprintf("\n  - %s: 缺少必填参数", e.Name))
+		case contract.ParamUnknown:
+			sb.WriteString(fmt.Sprintf("\n  - %s: 未知参数", e.Name))
+			if len(e.Suggestions) > 0 {
+				sb.WriteString(fmt.Sprintf("，是否想要: %s", strings.Join(e.Suggestions, ", ")))
+			}
+		default:
+			sb.WriteString(fmt.Sprintf("\n  - %s=%s: %v", e.Name, e.Value, e.Err))
+		}
+	}
+	return fmt.Errorf("%s", sb.String())
+}
+
+// printMatrix 以 Markdown 表格输出指定 kind 的转换支持矩阵
+// 行为源格式，列为目标格式；✅ 表示存在直接转换器，🔗 表示可经多跳转换，- 表示不支持
+func printMatrix(r contract.Ruyi, kind contract.Kind) error {
+	concepts := r.ListConcepts(kind)
+	if len(concepts) == 0 {
+		return fmt.Errorf("未知 kind 类型: %s", kind)
+	}
+
+	// 直接转换器
+	direct := make(map[contract.ConceptName]map[contract.ConceptName]bool)
+	for _, c := range r.ListConverters(kind) {
+		if direct[c.From().Name()] == nil {
+			direct[c.From().Name()] = make(map[contract.ConceptName]bool)
+		}
+		direct[c.From().Name()][c.To().Name()] = true
+	}
+
+	var sb strings.Builder
+
+	// 表头
+	sb.WriteString("| 源 \\ 目标 |")
+	for _, to := range concepts {
+		sb.WriteString(fmt.Sprintf(" %s |", strings.ToUpper(string(to.Name()))))
+	}
+	sb.WriteString("\n|:---|")
+	for range concepts {
+		sb.WriteString(":---:|")
+	}
+	sb.WriteString("\n")
+
+	// 表体
+	for _, from := range concepts {
+		reachable := make(map[contract.ConceptName]bool)
+		for _, to := range r.TargetsFrom(kind, from.Name()) {
+			reachable[to.Name()] = true
+		}
+
+		sb.WriteString(fmt.Sprintf("| **%s** |", strings.ToUpper(string(from.Name()))))
+		for _, to := range concepts {
+			mark := "-"
+			switch {
+			case direct[from.Name()][to.Name()]:
+				mark = "✅"
+			case reachable[to.Name()]:
+				mark = "🔗"
+			}
+			sb.WriteString(fmt.Sprintf(" %s |", mark))
+		}
+		sb.WriteString("\n")
+	}
+
+	fmt.Print(sb.String())
+	return nil
+}
+
+// printParamsJSONSchema 以 JSON Schema 格式输出转换器参数
+func printParamsJSONSchema(params []contract.ConverterParam) error {
+	data, err := json.MarshalIndent(contract.ParamsJSONSchema(params), "", "  ")
+	if err != nil {
+		return fmt.Errorf("生成 JSON Schema 失败: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
 // ParamMap 用于解析命令行中的 map 类型参数
 type ParamMap map[string]string
 
@@ -3,7 +3,38 @@ package core
 // 转换器参数定义
 
 const (
-	ParamWidth   = "width"   // 宽度
-	ParamHeight  = "height"  // 高度
-	ParamQuality = "quality" // 质量
+	ParamWidth         = "width"          // 宽度
+	ParamHeight        = "height"         // 高度
+	ParamQuality       = "quality"        // 质量
+	ParamResizeMode    = "resize_mode"    // 缩放模式
+	ParamGravity       = "gravity"        // 裁剪、填充时的锚点
+	ParamBackground    = "background"     // 背景色
+	ParamFilter        = "filter"         // 重采样滤镜
+	ParamNoUpscale     = "no_upscale"     // 禁止放大
+	ParamAutoOrient    = "auto_orient"    // 按 EXIF Orientation 自动旋转
+	ParamCrop          = "crop"           // 裁剪区域
+	ParamRotate        = "rotate"         // 旋转角度
+	ParamFlip          = "flip"           // 翻转方向
+	ParamGrayscale     = "grayscale"      // 灰度
+	ParamBrightness    = "brightness"     // 亮度
+	ParamContrast      = "contrast"       // 对比度
+	ParamGamma         = "gamma"          // gamma 校正
+	ParamBlur          = "blur"           // 高斯模糊
+	ParamSharpen       = "sharpen"        // 锐化
+	ParamNumColors     = "num_colors"     // 调色板颜色数
+	ParamQuantizer     = "quantizer"      // 颜色量化算法
+	ParamDither        = "dither"         // 抖动算法
+	ParamTransparency  = "transparency"   // 是否保留透明
+	ParamFrame         = "frame"          // 动画帧序号
+	ParamFrameMode     = "frame_mode"     // 动画帧输出模式
+	ParamSpriteColumns = "sprite_columns" // 精灵图列数
+	ParamSizes         = "sizes"          // ICO 输出的图像尺寸列表
+	ParamSize          = "size"           // ICO 输入选取的图像尺寸
+	ParamIndex         = "index"          // ICO 输入选取的图像序号
+
+	// 图标包
+	ParamAppName         = "app_name"         // Web 应用名称
+	ParamThemeColor      = "theme_color"      // Web 应用主题色
+	ParamIconPath        = "icon_path"        // 图标文件的 URL 路径前缀
+	ParamMaskablePadding = "maskable_padding" // maskable 图标的安全区留白
 )
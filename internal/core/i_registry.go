@@ -6,16 +6,50 @@ import (
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
 
-// ConverterRegistry 转换器注册器
+// ConverterRegistry 转换器注册器，实现需保证并发安全
 type ConverterRegistry interface {
 
-	// Register 注册转换器
+	// Register 注册转换器，与已注册的转换器重复时返回 exception.ErrConverterConflict
 	// @param converters 转换器列表
 	Register(converters ...contract.Converter) error
 
+	// Replace 注册转换器（替换模式），已存在相同 kind、from、to 的转换器时替换之，否则新增
+	// @param converters 转换器列表
+	Replace(converters ...contract.Converter) error
+
+	// Unregister 注销转换器，不存在时返回 exception.ErrNoSupportedConverter
+	// @param kind 种类
+	// @param from from名称
+	// @param to to名称
+	Unregister(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) error
+
 	// Find 查找转换器
 	// @param kind 种类
 	// @param from from名称
 	// @param to to名称
 	Find(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) contract.Converter
+
+	// FindPath 查找多跳转换路径
+	// @param kind 种类
+	// @param from from名称
+	// @param to to名称
+	// @return 代价最低的转换器路径（按执行顺序），不存在时返回 nil
+	FindPath(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) []contract.Converter
+
+	// Kinds 获取所有已注册转换器的种类（按名称排序）
+	Kinds() []contract.Kind
+
+	// List 获取指定种类下的所有直接转换器（按注册顺序）
+	// @param kind 种类
+	List(kind contract.Kind) []contract.Converter
+
+	// Targets 获取从 from 出发可达的所有目标 Concept（含多跳，按距离由近到远）
+	// @param kind 种类
+	// @param from from名称
+	Targets(kind contract.Kind, from contract.ConceptName) []contract.Concept
+
+	// Sources 获取可到达 to 的所有源 Concept（含多跳，按距离由近到远）
+	// @param kind 种类
+	// @param to to名称
+	Sources(kind contract.Kind, to contract.ConceptName) []contract.Concept
 }
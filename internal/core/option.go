@@ -0,0 +1,44 @@
+package core
+
+import (
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// Option Ruyi 实例配置项
+type Option func(*Options)
+
+// Options Ruyi 实例配置
+type Options struct {
+	// Converters 额外注册的转换器，不允许与已有转换器重复
+	Converters []contract.Converter
+
+	// Excluded 不注册的内置转换器
+	Excluded []ConverterKey
+
+	// Overrides 替换同 from -> to 的内置转换器
+	Overrides []contract.Converter
+
+	// StrictParams 是否启用严格参数模式：拒绝未声明的参数，可通过 contract.ContextWithStrictParams 按调用覆盖
+	StrictParams bool
+
+	// Limits 资源限制，为 nil 时使用 contract.DefaultLimits()，可通过 contract.ContextWithLimits 按调用覆盖
+	Limits *contract.Limits
+}
+
+// ConverterKey 转换器标识
+type ConverterKey struct {
+	From contract.ConceptName // 源 Concept 名称（支持别名）
+	To   contract.ConceptName // 目标 Concept 名称（支持别名）
+}
+
+// NewOptions 应用配置项，生成 Ruyi 实例配置
+// @param opts 配置项列表
+func NewOptions(opts []Option) *Options {
+	options := &Options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
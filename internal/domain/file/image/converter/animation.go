@@ -0,0 +1,204 @@
+package converter
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// Animation 动画图片的全部帧
+type Animation struct {
+	Frames    []*image.NRGBA // 合成后的完整画面，尺寸相同
+	Delays    []int          // 每帧的显示时长，单位：10 毫秒
+	LoopCount int            // 循环次数：0-无限循环，-1-不循环，n-播放 n+1 次（与 image/gif 一致）
+}
+
+// DecodeAnimationFunc 定义动画解码函数签名，静态图片返回只有一帧的动画
+type DecodeAnimationFunc func(r io.Reader, params map[string]string, opts AnimationDecodeOptions) (*Animation, error)
+
+// AnimationDecodeOptions 动画解码配置
+type AnimationDecodeOptions struct {
+	Limits contract.Limits // 资源限制：合成帧之前按帧数与画布尺寸校验全部帧的像素数之和
+	Frame  int             // 非负时只需要该帧：合成到该帧后停止解码，返回只包含该帧的动画；-1 表示解码全部帧
+}
+
+// EncodeAnimationFunc 定义动画编码函数签名
+type EncodeAnimationFunc func(w io.Writer, anim *Animation, params map[string]string) error
+
+// FrameMode 动画帧输出模式
+type FrameMode string
+
+const (
+	FrameModeAuto   FrameMode = "auto"   // 目标格式支持动画时保留全部帧，否则输出 frame 指定的帧（默认）
+	FrameModeSingle FrameMode = "single" // 输出 frame 指定的帧
+	FrameModeSprite FrameMode = "sprite" // 将全部帧拼接为一张精灵图
+)
+
+// frameModeAnimate auto 模式下目标格式支持动画时的实际模式：保留全部帧
+const frameModeAnimate FrameMode = "animate"
+
+// FrameOptions 动画帧配置，由 ParseFrameOptions 从转换器参数解析
+type FrameOptions struct {
+	Frame         int       // single 模式输出的帧序号
+	Mode          FrameMode // 输出模式
+	SpriteColumns int       // sprite 模式每行的帧数，0 表示全部帧排成一行
+}
+
+// ParseFrameOptions 解析动画相关参数（参数应已通过校验）
+func ParseFrameOptions(params map[string]string) FrameOptions {
+	opts := FrameOptions{Mode: FrameMode(strings.ToLower(params[ParamFrameMode]))}
+	opts.Frame, _ = strconv.Atoi(params[ParamFrame])
+	opts.SpriteColumns, _ = strconv.Atoi(params[ParamSpriteColumns])
+	if opts.Mode == "" {
+		opts.Mode = FrameModeAuto
+	}
+	return opts
+}
+
+// WithAnimation 声明源格式可能是动画，返回自身便于链式调用
+//
+// 说明:
+//
+//	decode 解码全部帧（单帧模式只解码到 frame 指定的帧，此后不再使用 NewBaseConverter 传入的 decode），并添加 frame、frame_mode、sprite_columns 参数；
+//	encode 非空时表示目标格式支持动画，auto 模式下保留全部帧，每一帧都会经过几何变换、缩放与色彩调整。
+func (c *BaseConverter) WithAnimation(decode DecodeAnimationFunc, encode EncodeAnimationFunc) *BaseConverter {
+	c.decodeAnimationFunc = decode
+	c.encodeAnimationFunc = encode
+	c.params.Append(NewFrameParams()...)
+	return c
+}
+
+// convertAnimation 按帧执行转换流程：Decode（全部帧或 frame 指定的帧） -> 按 frame_mode 选取、处理帧 -> Encode
+func (c *BaseConverter) convertAnimation(ctx context.Context, r io.Reader, w io.Writer, p pipeline) error {
+	opts := ParseFrameOptions(p.params)
+	mode := opts.Mode
+	if mode == FrameModeAuto && c.encodeAnimationFunc == nil {
+		mode = FrameModeSingle
+	}
+
+	// 1. 解码：单帧模式只解码到 frame 指定的帧，资源限制由解码函数在合成帧之前校验
+	decodeOpts := AnimationDecodeOptions{Limits: p.limits, Frame: -1}
+	if mode == FrameModeSingle {
+		decodeOpts.Frame = opts.Frame
+	}
+	var anim *Animation
+	err := c.decode(ctx, r, p, func(in io.Reader) (err error) {
+		if anim, err = c.decodeAnimationFunc(in, p.params, decodeOpts); err != nil {
+			return err
+		}
+		if len(anim.Frames) == 0 {
+			return exception.Errorf("animation has no frames")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	frame := 0
+	if mode == FrameModeAuto {
+		mode = frameModeAnimate
+		if len(anim.Frames) == 1 {
+			mode, frame = FrameModeSingle, opts.Frame
+		}
+	}
+
+	// 2. 单帧：按静态图片处理
+	if mode == FrameModeSingle {
+		if frame >= len(anim.Frames) {
+			return frameOutOfRange(frame, len(anim.Frames))
+		}
+		img, err := c.process(ctx, anim.Frames[frame], p)
+		if err != nil {
+			return err
+		}
+		return c.encode(ctx, w, func(w io.Writer) error {
+			return c.encodeFunc(w, img, p.params)
+		})
+	}
+
+	// 3. 多帧：逐帧处理
+	frames := make([]*image.NRGBA, 0, len(anim.Frames))
+	for _, frame := range anim.Frames {
+		img, err := c.process(ctx, frame, p)
+		if err != nil {
+			return err
+		}
+		frames = append(frames, img)
+	}
+
+	// 4. 编码
+	switch mode {
+	case FrameModeSprite:
+		sheet, err := spriteSheet(frames, opts.SpriteColumns, p.limits)
+		if err != nil {
+			return err
+		}
+		return c.encode(ctx, w, func(w io.Writer) error {
+			return c.encodeFunc(w, sheet, p.params)
+		})
+	default:
+		return c.encode(ctx, w, func(w io.Writer) error {
+			return c.encodeAnimationFunc(w, &Animation{Frames: frames, Delays: anim.Delays, LoopCount: anim.LoopCount}, p.params)
+		})
+	}
+}
+
+// frameOutOfRange frame 参数超出动画帧数的错误
+func frameOutOfRange(frame, frames int) error {
+	return &contract.ParamError{
+		Reason: contract.ParamInvalid,
+		Name:   ParamFrame,
+		Value:  strconv.Itoa(frame),
+		Err:    exception.Errorf("frame index out of range, the animation has %d frames", frames),
+	}
+}
+
+// spriteSheet 将尺寸相同的帧按从左到右、从上到下拼接为精灵图，columns 为 0 时全部帧排成一行
+func spriteSheet(frames []*image.NRGBA, columns int, limits contract.Limits) (*image.NRGBA, error) {
+	if columns <= 0 || columns > len(frames) {
+		columns = len(frames)
+	}
+	rows := (len(frames) + columns - 1) / columns
+	frameW, frameH := frames[0].Bounds().Dx(), frames[0].Bounds().Dy()
+	if err := limits.CheckOutputSize(frameW*columns, frameH*rows); err != nil {
+		return nil, err
+	}
+
+	sheet := image.NewNRGBA(image.Rect(0, 0, frameW*columns, frameH*rows))
+	for i, frame := range frames {
+		at := image.Pt(i%columns*frameW, i/columns*frameH)
+		draw.Draw(sheet, image.Rectangle{Min: at, Max: at.Add(image.Pt(frameW, frameH))}, frame, frame.Bounds().Min, draw.Src)
+	}
+	return sheet, nil
+}
+
+// encodeFrames 将每一帧分别编码为 PNG，打包为 ZIP，文件名为 frame_000.png 的形式
+func encodeFrames(w io.Writer, anim *Animation, params map[string]string) error {
+	zw := zip.NewWriter(w)
+	for i, frame := range anim.Frames {
+		entry, err := zw.Create(fmt.Sprintf("frame_%03d%s", i, contract.PNG().Extension()))
+		if err != nil {
+			return err
+		}
+		if err = png.Encode(entry, frame); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// encodeStaticFrames 将静态图片（单帧、精灵图）编码为只有一帧的逐帧图片包
+func encodeStaticFrames(w io.Writer, img image.Image, params map[string]string) error {
+	return encodeFrames(w, &Animation{Frames: []*image.NRGBA{imaging.Clone(img)}, Delays: []int{0}}, params)
+}
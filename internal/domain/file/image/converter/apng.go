@@ -0,0 +1,473 @@
+package converter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/disintegration/imaging"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// pngSignature PNG 文件签名
+const pngSignature = "\x89PNG\r\n\x1a\n"
+
+// APNG 帧控制块 (fcTL) 的处置方式 (dispose_op) 与混合方式 (blend_op)
+const (
+	apngDisposeNone       = 0 // 保留当前画面
+	apngDisposeBackground = 1 // 将帧区域清空为透明
+	apngDisposePrevious   = 2 // 恢复为绘制该帧之前的画面
+
+	apngBlendSource = 0 // 帧区域直接替换画面
+	apngBlendOver   = 1 // 帧区域按透明度叠加在画面上
+)
+
+// apngMaxChunkSize 编码时单个 IDAT / fdAT 块的最大数据长度
+const apngMaxChunkSize = 1 << 16
+
+// pngChunk PNG 数据块
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// apngFrame APNG 帧：帧控制块与图像数据（IDAT 或去掉序列号的 fdAT 数据）
+type apngFrame struct {
+	width, height      int
+	x, y               int
+	delayNum, delayDen uint16
+	dispose, blend     byte
+	data               [][]byte
+}
+
+// readPNGChunks 读取 PNG 签名之后的全部数据块，遇到 IEND 时结束
+func readPNGChunks(in []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(in, []byte(pngSignature)) {
+		return nil, exception.Errorf("not a png image")
+	}
+	var chunks []pngChunk
+	for i := len(pngSignature); ; {
+		if i+8 > len(in) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		size := int64(binary.BigEndian.Uint32(in[i:]))
+		end := int64(i) + 12 + size
+		if end > int64(len(in)) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		chunk := pngChunk{typ: string(in[i+4 : i+8]), data: in[i+8 : end-4]}
+		if crc32.ChecksumIEEE(in[i+4:end-4]) != binary.BigEndian.Uint32(in[end-4:]) {
+			return nil, exception.Errorf("invalid checksum of %s chunk", chunk.typ)
+		}
+		if chunk.typ == "IEND" {
+			return chunks, nil
+		}
+		chunks = append(chunks, chunk)
+		i = int(end)
+	}
+}
+
+// parseFCTL 解析帧控制块：sequence_number、width、height、x_offset、y_offset、delay_num、delay_den、dispose_op、blend_op
+func parseFCTL(data []byte) (*apngFrame, error) {
+	if len(data) != 26 {
+		return nil, exception.Errorf("invalid fcTL chunk length %d", len(data))
+	}
+	frame := &apngFrame{
+		width:    int(binary.BigEndian.Uint32(data[4:])),
+		height:   int(binary.BigEndian.Uint32(data[8:])),
+		x:        int(binary.BigEndian.Uint32(data[12:])),
+		y:        int(binary.BigEndian.Uint32(data[16:])),
+		delayNum: binary.BigEndian.Uint16(data[20:]),
+		delayDen: binary.BigEndian.Uint16(data[22:]),
+		dispose:  data[24],
+		blend:    data[25],
+	}
+	if frame.dispose > apngDisposePrevious || frame.blend > apngBlendOver {
+		return nil, exception.Errorf("invalid fcTL dispose_op %d or blend_op %d", frame.dispose, frame.blend)
+	}
+	return frame, nil
+}
+
+// decodeAPNG 解码 APNG，按各帧的混合方式 (blend_op)、处置方式 (dispose_op) 合成完整画面
+//
+// 说明:
+//
+//	没有 acTL 块的普通 PNG 返回只有一帧的动画；
+//	默认图像 (IDAT) 之前没有 fcTL 块时，默认图像不属于动画，会被跳过；
+//	acTL 声明的帧数 (num_frames) 必须与 fcTL 块的数量一致。
+//	合成前按帧数与画布尺寸校验全部帧的像素数；只需要一帧时合成到该帧为止，只保留该帧。每合成一帧之前检查 ctx。
+func decodeAPNG(r io.Reader, params map[string]string, opts AnimationDecodeOptions) (*Animation, error) {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := readPNGChunks(in)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) == 0 || chunks[0].typ != "IHDR" || len(chunks[0].data) != 13 {
+		return nil, exception.Errorf("missing IHDR chunk")
+	}
+	ihdr := chunks[0].data
+
+	var (
+		animated  bool
+		numFrames int
+		numPlays  int
+		header    []pngChunk // IDAT 之前、解码每一帧都需要的辅助块，如 PLTE、tRNS、gAMA
+		frames    []*apngFrame
+		current   *apngFrame
+		seenIDAT  bool
+	)
+	for _, chunk := range chunks[1:] {
+		switch chunk.typ {
+		case "acTL":
+			if len(chunk.data) != 8 {
+				return nil, exception.Errorf("invalid acTL chunk length %d", len(chunk.data))
+			}
+			animated = true
+			numFrames = int(binary.BigEndian.Uint32(chunk.data[0:]))
+			numPlays = int(binary.BigEndian.Uint32(chunk.data[4:]))
+		case "fcTL":
+			if current, err = parseFCTL(chunk.data); err != nil {
+				return nil, err
+			}
+			frames = append(frames, current)
+		case "IDAT":
+			seenIDAT = true
+			if current != nil { // 默认图像是动画的第一帧
+				current.data = append(current.data, chunk.data)
+			}
+		case "fdAT":
+			if current == nil || len(chunk.data) < 4 {
+				return nil, exception.Errorf("unexpected fdAT chunk")
+			}
+			current.data = append(current.data, chunk.data[4:])
+		default:
+			if !seenIDAT {
+				header = append(header, chunk)
+			}
+		}
+	}
+
+	if animated && numFrames != len(frames) {
+		return nil, exception.Errorf("acTL declares %d frames but found %d fcTL chunks", numFrames, len(frames))
+	}
+	if !animated || len(frames) == 0 {
+		img, err := png.Decode(bytes.NewReader(in))
+		if err != nil {
+			return nil, err
+		}
+		return &Animation{Frames: []*image.NRGBA{imaging.Clone(img)}, Delays: []int{0}}, nil
+	}
+
+	width, height := int(binary.BigEndian.Uint32(ihdr[0:])), int(binary.BigEndian.Uint32(ihdr[4:]))
+	canvasBounds := image.Rect(0, 0, width, height)
+	canvasPixels := int64(width) * int64(height)
+	for i, frame := range frames {
+		bounds := image.Rect(frame.x, frame.y, frame.x+frame.width, frame.y+frame.height)
+		if frame.width <= 0 || frame.height <= 0 || !bounds.In(canvasBounds) {
+			return nil, exception.Errorf("frame %d is outside the %dx%d canvas", i, width, height)
+		}
+	}
+	if opts.Frame >= 0 {
+		// 只保留一帧合成后的画面，但需要解码该帧及之前的全部帧
+		if opts.Frame >= len(frames) {
+			return nil, frameOutOfRange(opts.Frame, len(frames))
+		}
+		pixels := canvasPixels
+		for _, frame := range frames[:opts.Frame+1] {
+			pixels += int64(frame.width) * int64(frame.height)
+		}
+		if err = opts.Limits.CheckFramePixels(opts.Frame+1, pixels); err != nil {
+			return nil, err
+		}
+		frames = frames[:opts.Frame+1]
+	} else if err = opts.Limits.CheckFramePixels(len(frames), canvasPixels*int64(len(frames))); err != nil {
+		return nil, err
+	}
+
+	ctx := readerContext(r)
+	anim := &Animation{LoopCount: apngLoopCount(numPlays)}
+	canvas := image.NewNRGBA(canvasBounds)
+	for i, frame := range frames {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		bounds := image.Rect(frame.x, frame.y, frame.x+frame.width, frame.y+frame.height)
+		img, err := decodeAPNGFrame(ihdr, header, frame)
+		if err != nil {
+			return nil, exception.Wrapf(err, "decode frame %d failed", i)
+		}
+
+		// 恢复为上一画面时只需保存该帧覆盖的区域
+		var previous *image.NRGBA
+		if frame.dispose == apngDisposePrevious {
+			previous = imaging.Crop(canvas, bounds)
+		}
+		op := draw.Src
+		if frame.blend == apngBlendOver {
+			op = draw.Over
+		}
+		draw.Draw(canvas, bounds, img, img.Bounds().Min, op)
+		if opts.Frame < 0 || i == opts.Frame {
+			anim.Frames = append(anim.Frames, imaging.Clone(canvas))
+			anim.Delays = append(anim.Delays, apngDelay(frame.delayNum, frame.delayDen))
+		}
+
+		switch frame.dispose {
+		case apngDisposeBackground:
+			draw.Draw(canvas, bounds, image.Transparent, image.Point{}, draw.Src)
+		case apngDisposePrevious:
+			draw.Draw(canvas, bounds, previous, image.Point{}, draw.Src)
+		}
+	}
+	return anim, nil
+}
+
+// decodeAPNGFrame 将帧数据组装为独立的 PNG（修改 IHDR 中的尺寸）后使用 image/png 解码
+func decodeAPNGFrame(ihdr []byte, header []pngChunk, frame *apngFrame) (image.Image, error) {
+	var buf bytes.Buffer
+	buf.WriteString(pngSignature)
+
+	frameIHDR := append([]byte{}, ihdr...)
+	binary.BigEndian.PutUint32(frameIHDR[0:], uint32(frame.width))
+	binary.BigEndian.PutUint32(frameIHDR[4:], uint32(frame.height))
+	writePNGChunk(&buf, "IHDR", frameIHDR)
+	for _, chunk := range header {
+		writePNGChunk(&buf, chunk.typ, chunk.data)
+	}
+	for _, data := range frame.data {
+		writePNGChunk(&buf, "IDAT", data)
+	}
+	writePNGChunk(&buf, "IEND", nil)
+	return png.Decode(&buf)
+}
+
+// apngDelay 将 delay_num / delay_den 秒转换为 10 毫秒单位，delay_den 为 0 时按 100 处理
+func apngDelay(num, den uint16) int {
+	if den == 0 {
+		den = 100
+	}
+	return (int(num)*100 + int(den)/2) / int(den)
+}
+
+// apngLoopCount 将 APNG 的播放次数 (num_plays, 0 表示无限循环) 转换为 Animation.LoopCount
+func apngLoopCount(numPlays int) int {
+	switch numPlays {
+	case 0:
+		return 0
+	case 1:
+		return -1
+	default:
+		return numPlays - 1
+	}
+}
+
+// apngNumPlays 将 Animation.LoopCount 转换为 APNG 的播放次数，apngLoopCount 的逆运算
+func apngNumPlays(loopCount int) uint32 {
+	switch {
+	case loopCount == 0:
+		return 0
+	case loopCount < 0:
+		return 1
+	default:
+		return uint32(loopCount) + 1
+	}
+}
+
+// encodeAPNG 编码为 APNG，每一帧都是完整画面，第一帧同时作为默认图像
+//
+// 说明:
+//
+//	全部帧都不透明时使用 RGB，否则使用 RGBA，位深均为 8；
+//	每行按 PNG 标准的启发式规则（差值绝对值之和最小）选择滤波器。
+func encodeAPNG(w io.Writer, anim *Animation, params map[string]string) error {
+	if len(anim.Frames) == 0 {
+		return exception.Errorf("animation has no frames")
+	}
+	bounds := anim.Frames[0].Bounds()
+	opaque := true
+	for _, frame := range anim.Frames {
+		if !frame.Bounds().Size().Eq(bounds.Size()) {
+			return exception.Errorf("animation frames must have the same size")
+		}
+		opaque = opaque && frame.Opaque()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(pngSignature)
+
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(bounds.Dx()))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(bounds.Dy()))
+	ihdr[8] = 8 // 位深
+	ihdr[9] = 6 // RGBA
+	if opaque {
+		ihdr[9] = 2 // RGB
+	}
+	writePNGChunk(&buf, "IHDR", ihdr)
+
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(anim.Frames)))
+	binary.BigEndian.PutUint32(actl[4:], apngNumPlays(anim.LoopCount))
+	writePNGChunk(&buf, "acTL", actl)
+
+	var seq uint32
+	for i, frame := range anim.Frames {
+		delay := 0
+		if i < len(anim.Delays) {
+			delay = anim.Delays[i]
+		}
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(bounds.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(bounds.Dy()))
+		binary.BigEndian.PutUint16(fctl[20:], uint16(min(max(delay, 0), 0xFFFF)))
+		binary.BigEndian.PutUint16(fctl[22:], 100)
+		fctl[24], fctl[25] = apngDisposeNone, apngBlendSource
+		writePNGChunk(&buf, "fcTL", fctl)
+		seq++
+
+		data, err := compressPNGImage(frame, opaque)
+		if err != nil {
+			return err
+		}
+		for len(data) > 0 {
+			n := min(len(data), apngMaxChunkSize)
+			if i == 0 {
+				writePNGChunk(&buf, "IDAT", data[:n])
+			} else {
+				fdat := binary.BigEndian.AppendUint32(make([]byte, 0, 4+n), seq)
+				writePNGChunk(&buf, "fdAT", append(fdat, data[:n]...))
+				seq++
+			}
+			data = data[n:]
+		}
+	}
+	writePNGChunk(&buf, "IEND", nil)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// encodeStaticAPNG 将静态图片编码为只有一帧的 APNG
+func encodeStaticAPNG(w io.Writer, img image.Image, params map[string]string) error {
+	return encodeAPNG(w, &Animation{Frames: []*image.NRGBA{imaging.Clone(img)}, Delays: []int{0}}, params)
+}
+
+// compressPNGImage 对图片逐行滤波后使用 zlib 压缩，得到 IDAT 数据
+func compressPNGImage(img *image.NRGBA, opaque bool) ([]byte, error) {
+	bpp := 4
+	if opaque {
+		bpp = 3
+	}
+	bounds := img.Bounds()
+	rowLen := bounds.Dx() * bpp
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	prev := make([]byte, rowLen)
+	cur := make([]byte, rowLen)
+	filtered := make([][]byte, 5)
+	for i := range filtered {
+		filtered[i] = make([]byte, 1+rowLen)
+		filtered[i][0] = byte(i)
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := img.Pix[img.PixOffset(bounds.Min.X, y):img.PixOffset(bounds.Max.X, y)]
+		if opaque {
+			for x := 0; x < bounds.Dx(); x++ {
+				copy(cur[x*3:x*3+3], row[x*4:x*4+3])
+			}
+		} else {
+			copy(cur, row)
+		}
+
+		if _, err := zw.Write(filterPNGRow(cur, prev, bpp, filtered)); err != nil {
+			return nil, err
+		}
+		prev, cur = cur, prev
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// filterPNGRow 依次尝试 None、Sub、Up、Average、Paeth 滤波器，返回差值绝对值之和最小的结果（首字节为滤波器类型）
+func filterPNGRow(cur, prev []byte, bpp int, filtered [][]byte) []byte {
+	best, bestSum := 0, -1
+	for f := range filtered {
+		out := filtered[f][1:]
+		sum := 0
+		for i := range cur {
+			var a, b, c byte
+			if i >= bpp {
+				a, c = cur[i-bpp], prev[i-bpp]
+			}
+			b = prev[i]
+			switch f {
+			case 0:
+				out[i] = cur[i]
+			case 1:
+				out[i] = cur[i] - a
+			case 2:
+				out[i] = cur[i] - b
+			case 3:
+				out[i] = cur[i] - byte((int(a)+int(b))/2)
+			case 4:
+				out[i] = cur[i] - paeth(a, b, c)
+			}
+			sum += abs8(out[i])
+		}
+		if bestSum < 0 || sum < bestSum {
+			best, bestSum = f, sum
+		}
+	}
+	return filtered[best]
+}
+
+// paeth Paeth 预测器：返回 a（左）、b（上）、c（左上）中最接近 a + b - c 的值
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := p-int(a), p-int(b), p-int(c)
+	pa, pb, pc = max(pa, -pa), max(pb, -pb), max(pc, -pc)
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	default:
+		return c
+	}
+}
+
+// abs8 将字节视为有符号数时的绝对值
+func abs8(v byte) int {
+	if v < 128 {
+		return int(v)
+	}
+	return 256 - int(v)
+}
+
+// writePNGChunk 写入数据块：长度、类型、数据、CRC（类型与数据）
+func writePNGChunk(buf *bytes.Buffer, typ string, data []byte) {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	copy(header[4:], typ)
+	buf.Write(header[:])
+	buf.Write(data)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	buf.Write(sum[:])
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewAPNGToAPNGConverter APNG -> APNG 重新编码转换器，用于缩放、图片处理，默认保留动画（每一帧都会处理）
+func NewAPNGToAPNGConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.APNG(),
+		contract.APNG(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return png.Decode(r)
+		},
+		encodeStaticAPNG,
+	).WithAnimation(decodeAPNG, encodeAPNG)
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewAPNGToFramesConverter APNG -> 逐帧图片包转换器，每一帧分别编码为 PNG，打包为 ZIP
+func NewAPNGToFramesConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.APNG(),
+		contract.FRAMES(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return png.Decode(r)
+		},
+		encodeStaticFrames,
+	).WithAnimation(decodeAPNG, encodeFrames)
+}
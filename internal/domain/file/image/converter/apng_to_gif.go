@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewAPNGToGIFConverter APNG -> GIF 转换器，保留动画的帧时长与循环次数，每一帧按 GIF 编码参数量化
+func NewAPNGToGIFConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.APNG(),
+		contract.GIF(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return png.Decode(r)
+		},
+		encodeGIF,
+		NewGIFParams()...,
+	).WithAnimation(decodeAPNG, encodeGIFAnimation)
+}
@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewAPNGToPNGConverter APNG -> PNG 转换器，输出 frame 指定的帧，或通过 frame_mode 输出精灵图、逐帧 ZIP
+func NewAPNGToPNGConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.APNG(),
+		contract.PNG(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return png.Decode(r)
+		},
+		func(w io.Writer, img image.Image, params map[string]string) error {
+			return png.Encode(w, img)
+		},
+	).WithAnimation(decodeAPNG, nil)
+}
@@ -4,32 +4,44 @@ import (
 	"bytes"
 	"context"
 	"image"
+	"io"
 
-	"github.com/disintegration/imaging"
 	"github.com/wukong-app/ruyi/pkg/contract"
 	"github.com/wukong-app/ruyi/pkg/exception"
 )
 
 // DecodeFunc 定义解码函数签名
-type DecodeFunc func(r *bytes.Reader, params map[string]string) (image.Image, error)
+type DecodeFunc func(r io.Reader, params map[string]string) (image.Image, error)
 
 // EncodeFunc 定义编码函数签名
-type EncodeFunc func(w *bytes.Buffer, img image.Image, params map[string]string) error
+type EncodeFunc func(w io.Writer, img image.Image, params map[string]string) error
+
+// DecodeConfigFunc 定义读取图片头的函数签名，返回源图尺寸，并校验格式特有的资源限制（如像素数、SVG 元素个数）
+type DecodeConfigFunc func(r io.Reader, limits contract.Limits) (image.Config, error)
+
+var _ contract.StreamConverter = (*BaseConverter)(nil)
 
 // BaseConverter 是一个通用的图片转换器实现，封装了常见的 Convert 流程
 type BaseConverter struct {
-	from       contract.Concept
-	to         contract.Concept
-	params     contract.ConverterParams
-	decodeFunc DecodeFunc
-	encodeFunc EncodeFunc
+	from             contract.Concept
+	to               contract.Concept
+	params           contract.ConverterParams
+	decodeConfigFunc DecodeConfigFunc
+	decodeFunc       DecodeFunc
+	encodeFunc       EncodeFunc
+
+	decodeAnimationFunc DecodeAnimationFunc // 非空时按动画解码源图，见 WithAnimation
+	encodeAnimationFunc EncodeAnimationFunc // 非空时目标格式支持动画
 }
 
 // NewBaseConverter 创建一个新的通用转换器
 func NewBaseConverter(from, to contract.Concept, decode DecodeFunc, encode EncodeFunc, extraParams ...contract.ConverterParam) *BaseConverter {
-	// 默认添加 Width 和 Height 参数
+	// 默认添加缩放相关参数（width、height、resize_mode、gravity、background、no_upscale、filter）
+	// 与图片处理操作参数（crop、rotate、flip、grayscale、brightness、contrast、gamma、blur、sharpen）
 	params := contract.ConverterParams{}
-	params.Append(NewWidthParam(), NewHeightParam())
+	params.Append(NewResizeParams()...)
+	params.Append(NewFilterParam())
+	params.Append(NewOperationParams()...)
 
 	// 如果是 JPEG 相关的转换（通常 encodeFunc 需要 quality），可以由调用者通过 extraParams 传入 QualityParam
 	// 或者我们在这里判断？为了通用性，我们让调用者显式传递 QualityParam 如果他们需要。
@@ -40,14 +52,21 @@ func NewBaseConverter(from, to contract.Concept, decode DecodeFunc, encode Encod
 	}
 
 	return &BaseConverter{
-		from:       from,
-		to:         to,
-		params:     params,
-		decodeFunc: decode,
-		encodeFunc: encode,
+		from:             from,
+		to:               to,
+		params:           params,
+		decodeConfigFunc: decodeRasterConfig,
+		decodeFunc:       decode,
+		encodeFunc:       encode,
 	}
 }
 
+// WithDecodeConfig 替换读取图片头的函数，默认使用 image.DecodeConfig 并校验像素数，返回自身便于链式调用
+func (c *BaseConverter) WithDecodeConfig(decodeConfig DecodeConfigFunc) *BaseConverter {
+	c.decodeConfigFunc = decodeConfig
+	return c
+}
+
 func (c *BaseConverter) From() contract.Concept {
 	return c.from
 }
@@ -65,40 +84,148 @@ func (c *BaseConverter) Params() []contract.ConverterParam {
 	return params
 }
 
-// Convert 执行标准的转换流程：CheckParams -> Decode -> Resize -> Encode
+// Convert 执行标准的转换流程：CheckParams -> Decode（含 AutoOrient） -> Transform -> Resize -> Adjust -> Encode
 func (c *BaseConverter) Convert(ctx context.Context, in []byte, params map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.ConvertStream(ctx, bytes.NewReader(in), &buf, params); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ConvertStream 流式执行标准的转换流程：CheckParams -> Decode（含 AutoOrient） -> Transform -> Resize -> Adjust -> Encode
+// 注意：编码失败时 w 中可能已写入部分数据，由调用方负责丢弃。
+// ctx 结束时尽快返回 ctx 的错误（见 runStage），此后不会再发起对 r、w 的新读写。
+func (c *BaseConverter) ConvertStream(ctx context.Context, r io.Reader, w io.Writer, params map[string]string) error {
 	// 1. 参数校验
 	checkedParams, err := c.params.CheckAndGetParams(params)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// 2. 解析参数
-	width, height := ParseResizeParams(checkedParams)
+	p := pipeline{
+		params: checkedParams,
+		resize: ParseResizeOptions(checkedParams),
+		ops:    ParseOperations(checkedParams),
+		limits: contract.LimitsOf(ctx),
+	}
+
+	// 动画源格式按帧处理，见 convertAnimation
+	if c.decodeAnimationFunc != nil {
+		return c.convertAnimation(ctx, r, w, p)
+	}
 
 	// 3. 解码
-	img, err := c.decodeFunc(bytes.NewReader(in), checkedParams)
+	var img image.Image
+	err = c.decode(ctx, r, p, func(in io.Reader) (err error) {
+		img, err = c.decodeFunc(in, p.params)
+		return err
+	})
 	if err != nil {
-		return nil, exception.Wrapf(exception.Join(exception.ErrConvertFailed, err), "image decode failed")
+		return err
 	}
 
-	// 4. 缩放 (Resize)
-	// 注意：部分格式（如 HEIC）可能返回 YCbCr，如果直接 Encode 为 PNG 可能会有问题。
-	// imaging.Resize 返回的是 NRGBA，这很好。
-	// 如果不缩放，我们也应该确保图片格式的兼容性（特别是对于 HEIC -> PNG 这种场景）。
-	if width > 0 || height > 0 {
-		img = imaging.Resize(img, int(width), int(height), imaging.Lanczos)
-	} else {
-		// 强制转换为 NRGBA/RGBA 以确保最大兼容性 (解决如 HEIC YCbCr -> PNG 的问题)
-		// imaging.Clone 会标准化图像格式
-		img = imaging.Clone(img)
+	// 4. 几何变换、缩放、色彩调整
+	out, err := c.process(ctx, img, p)
+	if err != nil {
+		return err
 	}
 
 	// 5. 编码
-	var buf bytes.Buffer
-	if err := c.encodeFunc(&buf, img, checkedParams); err != nil {
-		return nil, exception.Wrapf(exception.Join(exception.ErrConvertFailed, err), "image encode failed")
+	return c.encode(ctx, w, func(w io.Writer) error {
+		return c.encodeFunc(w, out, p.params)
+	})
+}
+
+// pipeline 单次转换解析后的参数
+type pipeline struct {
+	params map[string]string // 已校验并补齐默认值的参数
+	resize ResizeOptions     // 缩放配置
+	ops    Operations        // 图片处理操作
+	limits contract.Limits   // 资源限制
+}
+
+// decode 解码阶段：解码前读取图片头，校验像素数与输出尺寸，再调用 decode 解码重放的完整输入
+//
+// 说明:
+//
+//	解码时可能按 EXIF Orientation 旋转图片，宽高互换，因此解码前两种方向之一满足限制即可，几何变换后再按实际尺寸校验。
+func (c *BaseConverter) decode(ctx context.Context, r io.Reader, p pipeline, decode func(in io.Reader) error) error {
+	err := runStage(ctx, func() error {
+		reader := newCtxReader(ctx, r)
+		defer reader.Close()
+		in, config, err := readDecodeConfig(reader, p.limits, c.decodeConfigFunc)
+		if err != nil {
+			return err
+		}
+		if err = checkOutputSize(p.limits, p.resize, p.ops, config.Width, config.Height); err != nil &&
+			checkOutputSize(p.limits, p.resize, p.ops, config.Height, config.Width) != nil {
+			return err
+		}
+		// 存在裁剪、旋转时解码结果为源图尺寸（SVG 按 viewBox 尺寸光栅化），同样受像素数限制
+		if p.ops.Geometric() {
+			if err = p.limits.CheckPixels(config.Width, config.Height); err != nil {
+				return err
+			}
+		}
+		return decode(in)
+	})
+	if err != nil {
+		if exception.Is(err, exception.ErrLimitExceeded) || exception.Is(err, exception.ErrIllegalConverterParam) {
+			return err
+		}
+		return stageError(ctx, err, exception.ErrDecodeFailed, "image decode")
 	}
+	return nil
+}
 
-	return buf.Bytes(), nil
+// process 依次执行几何变换（裁剪、旋转、翻转）、缩放与色彩调整、滤镜，返回 NRGBA 图片
+func (c *BaseConverter) process(ctx context.Context, img image.Image, p pipeline) (*image.NRGBA, error) {
+	// 几何变换，并按变换后的实际尺寸校验输出尺寸
+	err := runStage(ctx, func() error {
+		transformed, err := p.ops.Transform(ctx, img)
+		if err != nil {
+			return err
+		}
+		img = transformed
+		return checkOutputSize(p.limits, p.resize, Operations{}, img.Bounds().Dx(), img.Bounds().Dy())
+	})
+	if err != nil {
+		if exception.Is(err, exception.ErrLimitExceeded) || exception.Is(err, exception.ErrIllegalConverterParam) {
+			return nil, err
+		}
+		return nil, stageError(ctx, err, exception.ErrConvertFailed, "image transform")
+	}
+
+	// 缩放 (Resize) 与色彩调整、滤镜 (Adjust)
+	// 注意：部分格式（如 HEIC）可能返回 YCbCr，如果直接 Encode 为 PNG 可能会有问题。
+	// Apply 总是返回 NRGBA（不缩放时使用 imaging.Clone 标准化图像格式），以确保最大兼容性。
+	var out *image.NRGBA
+	err = runStage(ctx, func() error {
+		resized, err := p.resize.Apply(ctx, img)
+		if err != nil {
+			return err
+		}
+		out, err = p.ops.Adjust(ctx, resized)
+		return err
+	})
+	if err != nil {
+		return nil, stageError(ctx, err, exception.ErrConvertFailed, "image resize")
+	}
+	return out, nil
+}
+
+// encode 编码阶段：encode 写入的 Writer 在 ctx 结束后拒绝写入
+func (c *BaseConverter) encode(ctx context.Context, w io.Writer, encode func(w io.Writer) error) error {
+	err := runStage(ctx, func() error {
+		return encode(&ctxWriter{ctx: ctx, w: w})
+	})
+	if err != nil {
+		if exception.Is(err, exception.ErrLimitExceeded) {
+			return err
+		}
+		return stageError(ctx, err, exception.ErrEncodeFailed, "image encode")
+	}
+	return nil
 }
@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"image"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"golang.org/x/image/bmp"
+)
+
+// NewBMPToBMPConverter BMP -> BMP 重新编码转换器，用于缩放、图片处理
+func NewBMPToBMPConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.BMP(),
+		contract.BMP(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return bmp.Decode(r)
+		},
+		func(w io.Writer, img image.Image, params map[string]string) error {
+			return bmp.Encode(w, img)
+		},
+	)
+}
@@ -1,9 +1,8 @@
 package converter
 
 import (
-	"bytes"
 	"image"
-	"image/jpeg"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 	"golang.org/x/image/bmp"
@@ -13,12 +12,11 @@ func NewBMPToJPEGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.BMP(),
 		contract.JPEG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		func(r io.Reader, params map[string]string) (image.Image, error) {
 			return bmp.Decode(r)
 		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
-			return jpeg.Encode(w, img, &jpeg.Options{Quality: ParseQualityParam(params)})
-		},
+		encodeJPEG,
 		NewQualityParam(),
+		NewFlattenBackgroundParam(),
 	)
 }
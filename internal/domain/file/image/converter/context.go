@@ -0,0 +1,208 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// cancelBandPixels 分带处理时每带的像素数，每处理完一带检查一次 ctx
+const cancelBandPixels = 1 << 20
+
+// ctxReader ctx 结束后 Read 立即返回 ctx 的错误，不再消费底层 Reader
+//
+// 说明:
+//
+//	内存中的 Reader 直接读取；其他 Reader（网络、管道）的读取可能一直阻塞，
+//	由一个 goroutine 代为执行，ctx 结束时放弃等待，该 goroutine 在当前读取返回后退出。
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+
+	reqs   chan []byte     // 代为执行的读取请求，nil 表示尚未启动
+	resps  chan readResult // 读取结果
+	buf    []byte          // 代为读取的缓冲区，放弃等待后不再复用
+	closed bool
+}
+
+// readResult 代为执行的读取结果
+type readResult struct {
+	n   int
+	err error
+}
+
+func newCtxReader(ctx context.Context, r io.Reader) *ctxReader {
+	return &ctxReader{ctx: ctx, r: r}
+}
+
+// Context 返回读取所属转换的 ctx，见 readerContext
+func (s *ctxReader) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ctxReader) Read(p []byte) (int, error) {
+	if err := s.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if s.ctx.Done() == nil || inMemory(s.r) {
+		return s.r.Read(p)
+	}
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	if s.reqs == nil {
+		s.reqs, s.resps = make(chan []byte), make(chan readResult, 1)
+		go func(r io.Reader, reqs <-chan []byte, resps chan<- readResult) {
+			for buf := range reqs {
+				n, err := r.Read(buf)
+				resps <- readResult{n: n, err: err}
+			}
+		}(s.r, s.reqs, s.resps)
+	}
+	if cap(s.buf) < len(p) {
+		s.buf = make([]byte, len(p))
+	}
+	buf := s.buf[:len(p)]
+	s.reqs <- buf
+
+	select {
+	case res := <-s.resps:
+		return copy(p, buf[:res.n]), res.err
+	case <-s.ctx.Done():
+		s.buf = nil
+		s.Close()
+		return 0, s.ctx.Err()
+	}
+}
+
+// Close 结束代为读取的 goroutine，不关闭底层 Reader
+func (s *ctxReader) Close() error {
+	if !s.closed && s.reqs != nil {
+		close(s.reqs)
+	}
+	s.closed = true
+	return nil
+}
+
+// inMemory 是否为内存中的 Reader，读取不会阻塞
+func inMemory(r io.Reader) bool {
+	switch r.(type) {
+	case *bytes.Reader, *bytes.Buffer, *strings.Reader:
+		return true
+	default:
+		return false
+	}
+}
+
+// readerContext 返回 r 所属转换的 ctx，使解码函数中的耗时计算（如 SVG 光栅化）可以响应取消
+func readerContext(r io.Reader) context.Context {
+	if cr, ok := r.(interface{ Context() context.Context }); ok {
+		return cr.Context()
+	}
+	return context.Background()
+}
+
+// ctxWriter ctx 结束后 Write 立即返回 ctx 的错误，使编码阶段在下一次写入时退出，不再写入底层 Writer
+type ctxWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (s *ctxWriter) Write(p []byte) (int, error) {
+	if err := s.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return s.w.Write(p)
+}
+
+// runStage 执行耗时阶段（解码、几何变换、缩放、编码）
+//
+// 说明:
+//
+//	阶段开始前与结束后检查 ctx，ctx 已结束时返回 ctx 的错误，后续阶段不再启动；
+//	阶段内部的耗时计算分带执行（见 inRowBands），读写经由 ctxReader / ctxWriter，ctx 结束后尽快退出。
+//	阶段内的 panic 转为 ErrInternal。
+func runStage(ctx context.Context, fn func() error) (err error) {
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = exception.Wrapf(exception.ErrInternal, "converter panic: %v", r)
+		}
+	}()
+
+	if err = fn(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// stageError 包装阶段错误：ctx 已结束时返回 ctx 的错误（满足 context.Canceled / context.DeadlineExceeded），
+// 否则与 sentinel 合并
+func stageError(ctx context.Context, err error, sentinel error, stage string) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return exception.Wrapf(ctxErr, "%s canceled", stage)
+	}
+	return exception.Wrapf(exception.Join(sentinel, err), "%s failed", stage)
+}
+
+// subImager 支持 SubImage 的图片，标准库的图片类型均已实现
+type subImager interface {
+	image.Image
+	SubImage(r image.Rectangle) image.Image
+}
+
+// inRowBands 将 src 按行分带交给 fn 处理，拼接为 width 宽的结果，每带之间检查 ctx
+//
+// 说明:
+//
+//	每带上下各多取 margin 行作为邻域（如模糊半径），fn 返回的图片与传入的带等高，只取回不含邻域的行；
+//	fn 对每一行的处理只依赖 margin 行以内的像素时，结果与整张图片一次处理相同。
+func inRowBands(ctx context.Context, src image.Image, width, margin int, fn func(band image.Image) *image.NRGBA) (*image.NRGBA, error) {
+	si, ok := src.(subImager)
+	if !ok {
+		si = imaging.Clone(src)
+	}
+	b := si.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, b.Dy()))
+	step := max(1, cancelBandPixels/max(1, b.Dx()))
+	for y0 := 0; y0 < b.Dy(); y0 += step {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		y1 := min(y0+step, b.Dy())
+		lo, hi := max(0, y0-margin), min(b.Dy(), y1+margin)
+		out := fn(si.SubImage(image.Rect(b.Min.X, b.Min.Y+lo, b.Max.X, b.Min.Y+hi)))
+		copy(dst.Pix[y0*dst.Stride:y1*dst.Stride], out.Pix[(y0-lo)*out.Stride:(y1-lo)*out.Stride])
+	}
+	return dst, nil
+}
+
+// inColumnBands 将 src 按列分带交给 fn 处理，拼接为 height 高的结果，每带之间检查 ctx
+//
+// 说明:
+//
+//	fn 返回的图片与传入的带等宽；fn 对每一列的处理只依赖该列时，结果与整张图片一次处理相同。
+func inColumnBands(ctx context.Context, src *image.NRGBA, height int, fn func(band image.Image) *image.NRGBA) (*image.NRGBA, error) {
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), height))
+	step := max(1, cancelBandPixels/max(1, b.Dy(), height))
+	for x0 := 0; x0 < b.Dx(); x0 += step {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		x1 := min(x0+step, b.Dx())
+		out := fn(src.SubImage(image.Rect(b.Min.X+x0, b.Min.Y, b.Min.X+x1, b.Max.Y)))
+		for y := 0; y < height; y++ {
+			copy(dst.Pix[y*dst.Stride+x0*4:y*dst.Stride+x1*4], out.Pix[y*out.Stride:y*out.Stride+(x1-x0)*4])
+		}
+	}
+	return dst, nil
+}
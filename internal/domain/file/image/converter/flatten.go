@@ -0,0 +1,37 @@
+package converter
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// flatten 将图片铺在背景色上并去除透明度，用于不支持透明的输出格式（如 JPEG）
+//
+// 说明:
+//
+//	背景色本身带透明度时先与白色混合，保证结果完全不透明。
+func flatten(img image.Image, background color.NRGBA) *image.RGBA {
+	out := image.NewRGBA(img.Bounds())
+	draw.Draw(out, out.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Over)
+	draw.Draw(out, out.Bounds(), img, img.Bounds().Min, draw.Over)
+	return out
+}
+
+// ParseBackground 解析 background 参数（参数应已通过校验），未设置时返回白色
+func ParseBackground(params map[string]string) color.NRGBA {
+	if bg, err := contract.ParseColor(params[ParamBackground]); err == nil && params[ParamBackground] != "" {
+		return bg
+	}
+	return color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+}
+
+// encodeJPEG 按 background 参数铺底后，按 quality 参数编码为 JPEG
+func encodeJPEG(w io.Writer, img image.Image, params map[string]string) error {
+	return jpeg.Encode(w, flatten(img, ParseBackground(params)), &jpeg.Options{Quality: ParseQualityParam(params)})
+}
@@ -0,0 +1,318 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// Quantizer 生成 GIF 调色板的颜色量化算法
+type Quantizer string
+
+const (
+	QuantizerMedianCut Quantizer = "median-cut" // 中位切分，按图片颜色分布生成自适应调色板（默认）
+	QuantizerOctree    Quantizer = "octree"     // 八叉树，生成自适应调色板，速度较快
+	QuantizerPlan9     Quantizer = "plan9"      // 固定的 Plan9 调色板，不受 num_colors 影响（保留透明时使用前 255 色）
+)
+
+// Dither 抖动算法
+type Dither string
+
+const (
+	DitherNone           Dither = "none"            // 不抖动，映射到最接近的颜色，色块清晰，适合图标、像素画
+	DitherFloydSteinberg Dither = "floyd-steinberg" // Floyd-Steinberg 误差扩散，渐变更平滑，适合照片（默认）
+)
+
+// alphaThreshold 透明度阈值：alpha 低于该值的像素在 GIF 中视为完全透明，否则视为不透明
+const alphaThreshold = 0x80
+
+// GIFOptions GIF 编码配置，由 ParseGIFOptions 从转换器参数解析
+type GIFOptions struct {
+	NumColors    int         // 调色板颜色数（含透明色），范围 [2, 256]
+	Quantizer    Quantizer   // 颜色量化算法
+	Dither       Dither      // 抖动算法
+	Transparency bool        // 是否保留透明：为 true 时占用调色板的一个颜色作为透明色
+	Background   color.NRGBA // 不保留透明时，透明像素铺底使用的背景色
+}
+
+// ParseGIFOptions 解析 GIF 编码相关参数（参数应已通过校验）
+func ParseGIFOptions(params map[string]string) GIFOptions {
+	opts := GIFOptions{
+		NumColors:    256,
+		Quantizer:    Quantizer(strings.ToLower(params[ParamQuantizer])),
+		Dither:       Dither(strings.ToLower(params[ParamDither])),
+		Transparency: true,
+		Background:   ParseBackground(params),
+	}
+	if n, err := strconv.Atoi(params[ParamNumColors]); err == nil && n >= 2 && n <= 256 {
+		opts.NumColors = n
+	}
+	if opts.Quantizer == "" {
+		opts.Quantizer = QuantizerMedianCut
+	}
+	if opts.Dither == "" {
+		opts.Dither = DitherFloydSteinberg
+	}
+	if v, err := strconv.ParseBool(params[ParamTransparency]); err == nil {
+		opts.Transparency = v
+	}
+	return opts
+}
+
+// Paletted 将图片量化为调色板图片
+//
+// 说明:
+//
+//	保留透明且图片包含透明像素（alpha 低于 128）时，调色板最后一个颜色为透明色，其余颜色由量化算法生成；
+//	不保留透明时，先将图片铺在 Background 上。
+func (o GIFOptions) Paletted(img image.Image) *image.Paletted {
+	src := imaging.Clone(img)
+	if !o.Transparency {
+		src = imaging.Clone(flatten(src, o.Background))
+	}
+
+	transparent := o.Transparency && hasTransparency(src)
+	numColors := o.NumColors
+	if o.Quantizer == QuantizerPlan9 {
+		numColors = len(palette.Plan9)
+	}
+	if transparent {
+		numColors--
+	}
+
+	var pal color.Palette
+	switch o.Quantizer {
+	case QuantizerPlan9:
+		pal = append(color.Palette{}, palette.Plan9[:numColors]...)
+	case QuantizerOctree:
+		pal = octreeQuantize(colorHistogram(src), numColors)
+	default:
+		pal = medianCut(colorHistogram(src), numColors)
+	}
+	if len(pal) == 0 { // 图片完全透明
+		pal = color.Palette{color.Black}
+	}
+
+	// 先将全部像素视为不透明完成映射（抖动误差不会扩散到透明区域之外），再标记透明像素
+	opaque := imaging.Clone(src)
+	for i := 3; i < len(opaque.Pix); i += 4 {
+		opaque.Pix[i] = 0xFF
+	}
+	bounds := src.Bounds()
+	dst := image.NewPaletted(bounds, pal)
+	if o.Dither == DitherNone {
+		draw.Draw(dst, bounds, opaque, bounds.Min, draw.Src)
+	} else {
+		draw.FloydSteinberg.Draw(dst, bounds, opaque, bounds.Min)
+	}
+
+	// src 与 dst 的坐标均从 (0, 0) 开始，像素一一对应
+	if transparent {
+		index := uint8(len(dst.Palette))
+		dst.Palette = append(dst.Palette, color.NRGBA{})
+		for i := range dst.Pix {
+			if src.Pix[i*4+3] < alphaThreshold {
+				dst.Pix[i] = index
+			}
+		}
+	}
+	return dst
+}
+
+// hasTransparency 图片是否包含透明像素（alpha 低于 alphaThreshold）
+func hasTransparency(img *image.NRGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] < alphaThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeGIF 按 GIF 编码参数量化后编码为 GIF
+func encodeGIF(w io.Writer, img image.Image, params map[string]string) error {
+	paletted := ParseGIFOptions(params).Paletted(img)
+	return gif.Encode(w, paletted, &gif.Options{NumColors: len(paletted.Palette)})
+}
+
+// gifFrameInfo GIF 中一帧的位置与数据范围，由 scanGIF 读取
+type gifFrameInfo struct {
+	bounds image.Rectangle // 帧在画布中的区域
+	end    int             // 帧数据结束的偏移
+}
+
+// scanGIF 只读取 GIF 的块结构（不解压图像数据），返回画布尺寸与每一帧的区域
+func scanGIF(in []byte) (width, height int, frames []gifFrameInfo, err error) {
+	const (
+		headerSize     = 6 + 7 // 签名与逻辑屏幕描述符
+		descriptorSize = 9     // 图像描述符（不含 0x2C）
+		colorTableFlag = 0x80
+		colorTableSize = 0x07
+		blockExtension = 0x21
+		blockImage     = 0x2C
+		blockTrailer   = 0x3B
+	)
+	if len(in) < headerSize || string(in[:3]) != "GIF" {
+		return 0, 0, nil, exception.Errorf("not a gif image")
+	}
+	width, height = int(binary.LittleEndian.Uint16(in[6:])), int(binary.LittleEndian.Uint16(in[8:]))
+	pos := headerSize
+	if in[10]&colorTableFlag != 0 {
+		pos += 3 << (in[10]&colorTableSize + 1)
+	}
+
+	// skipSubBlocks 跳过以长度为 0 的子块结尾的数据子块
+	skipSubBlocks := func() error {
+		for {
+			if pos >= len(in) {
+				return io.ErrUnexpectedEOF
+			}
+			n := int(in[pos])
+			pos += 1 + n
+			if n == 0 {
+				return nil
+			}
+		}
+	}
+	for {
+		if pos >= len(in) {
+			return 0, 0, nil, io.ErrUnexpectedEOF
+		}
+		switch in[pos] {
+		case blockExtension:
+			pos += 2
+			if err = skipSubBlocks(); err != nil {
+				return 0, 0, nil, err
+			}
+		case blockImage:
+			if pos+1+descriptorSize >= len(in) {
+				return 0, 0, nil, io.ErrUnexpectedEOF
+			}
+			d := in[pos+1:]
+			x, y := int(binary.LittleEndian.Uint16(d[0:])), int(binary.LittleEndian.Uint16(d[2:]))
+			w, h := int(binary.LittleEndian.Uint16(d[4:])), int(binary.LittleEndian.Uint16(d[6:]))
+			pos += 1 + descriptorSize
+			if d[8]&colorTableFlag != 0 {
+				pos += 3 << (d[8]&colorTableSize + 1)
+			}
+			pos++ // LZW 最小码长
+			if err = skipSubBlocks(); err != nil {
+				return 0, 0, nil, err
+			}
+			frames = append(frames, gifFrameInfo{bounds: image.Rect(x, y, x+w, y+h), end: pos})
+		case blockTrailer:
+			return width, height, frames, nil
+		default:
+			return 0, 0, nil, exception.Errorf("unexpected gif block 0x%02x at offset %d", in[pos], pos)
+		}
+	}
+}
+
+// decodeGIFAnimation 解码 GIF，按各帧的处置方式 (disposal) 合成完整画面
+//
+// 说明:
+//
+//	解码前读取块结构，按帧数与画布尺寸校验合成后全部帧的像素数；
+//	只需要一帧时截断到该帧之后再解码，合成到该帧为止，只保留该帧。每合成一帧之前检查 ctx。
+func decodeGIFAnimation(r io.Reader, params map[string]string, opts AnimationDecodeOptions) (*Animation, error) {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	width, height, infos, err := scanGIF(in)
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, exception.Errorf("gif has no frames")
+	}
+	bounds := image.Rect(0, 0, width, height)
+	if bounds.Empty() {
+		bounds = image.Rect(0, 0, infos[0].bounds.Max.X, infos[0].bounds.Max.Y)
+	}
+	if err = opts.Limits.CheckPixels(bounds.Dx(), bounds.Dy()); err != nil {
+		return nil, err
+	}
+
+	canvasPixels := int64(bounds.Dx()) * int64(bounds.Dy())
+	if opts.Frame >= 0 {
+		// 只保留一帧合成后的画面，但解码器会保存该帧及之前的全部调色板图像
+		if opts.Frame >= len(infos) {
+			return nil, frameOutOfRange(opts.Frame, len(infos))
+		}
+		pixels := canvasPixels
+		for _, info := range infos[:opts.Frame+1] {
+			pixels += int64(info.bounds.Dx()) * int64(info.bounds.Dy())
+		}
+		if err = opts.Limits.CheckFramePixels(opts.Frame+1, pixels); err != nil {
+			return nil, err
+		}
+		end := infos[opts.Frame].end
+		in = append(in[:end:end], 0x3B)
+	} else if err = opts.Limits.CheckFramePixels(len(infos), canvasPixels*int64(len(infos))); err != nil {
+		return nil, err
+	}
+
+	g, err := gif.DecodeAll(bytes.NewReader(in))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := readerContext(r)
+	anim := &Animation{LoopCount: g.LoopCount}
+	canvas := image.NewNRGBA(bounds)
+	for i, frame := range g.Image {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		// 恢复为上一画面时只需保存该帧覆盖的区域
+		var previous *image.NRGBA
+		if disposal == gif.DisposalPrevious {
+			previous = imaging.Crop(canvas, frame.Bounds())
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		if opts.Frame < 0 || i == opts.Frame {
+			delay := 0
+			if i < len(g.Delay) {
+				delay = g.Delay[i]
+			}
+			anim.Frames = append(anim.Frames, imaging.Clone(canvas))
+			anim.Delays = append(anim.Delays, delay)
+		}
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			draw.Draw(canvas, frame.Bounds().Intersect(bounds), previous, image.Point{}, draw.Src)
+		}
+	}
+	return anim, nil
+}
+
+// encodeGIFAnimation 按 GIF 编码参数逐帧量化后编码为 GIF 动画，每一帧都是完整画面，显示下一帧前清空
+func encodeGIFAnimation(w io.Writer, anim *Animation, params map[string]string) error {
+	opts := ParseGIFOptions(params)
+	g := &gif.GIF{LoopCount: anim.LoopCount}
+	for i, frame := range anim.Frames {
+		g.Image = append(g.Image, opts.Paletted(frame))
+		g.Delay = append(g.Delay, anim.Delays[i])
+		g.Disposal = append(g.Disposal, gif.DisposalBackground)
+	}
+	return gif.EncodeAll(w, g)
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"image"
+	"image/gif"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewGIFToAPNGConverter GIF -> APNG 转换器，保留动画的帧时长与循环次数，透明像素不再受 GIF 二值透明的限制
+func NewGIFToAPNGConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.GIF(),
+		contract.APNG(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return gif.Decode(r)
+		},
+		encodeStaticAPNG,
+	).WithAnimation(decodeGIFAnimation, encodeAPNG)
+}
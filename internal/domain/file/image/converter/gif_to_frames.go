@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"image"
+	"image/gif"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewGIFToFramesConverter GIF -> 逐帧图片包转换器，每一帧分别编码为 PNG，打包为 ZIP
+func NewGIFToFramesConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.GIF(),
+		contract.FRAMES(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return gif.Decode(r)
+		},
+		encodeStaticFrames,
+	).WithAnimation(decodeGIFAnimation, encodeFrames)
+}
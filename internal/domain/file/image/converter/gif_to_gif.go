@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"image"
+	"image/gif"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewGIFToGIFConverter GIF -> GIF 重新编码转换器，用于缩放、图片处理，默认保留动画（每一帧都会处理）
+func NewGIFToGIFConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.GIF(),
+		contract.GIF(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return gif.Decode(r)
+		},
+		encodeGIF,
+		NewGIFParams()...,
+	).WithAnimation(decodeGIFAnimation, encodeGIFAnimation)
+}
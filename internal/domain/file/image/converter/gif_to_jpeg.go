@@ -1,10 +1,9 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/gif"
-	"image/jpeg"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
@@ -13,12 +12,11 @@ func NewGIFToJPEGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.GIF(),
 		contract.JPEG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		func(r io.Reader, params map[string]string) (image.Image, error) {
 			return gif.Decode(r)
 		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
-			return jpeg.Encode(w, img, &jpeg.Options{Quality: ParseQualityParam(params)})
-		},
+		encodeJPEG,
 		NewQualityParam(),
-	)
+		NewFlattenBackgroundParam(),
+	).WithAnimation(decodeGIFAnimation, nil)
 }
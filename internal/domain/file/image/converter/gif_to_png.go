@@ -1,10 +1,10 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/gif"
 	"image/png"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
@@ -13,11 +13,11 @@ func NewGIFToPNGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.GIF(),
 		contract.PNG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		func(r io.Reader, params map[string]string) (image.Image, error) {
 			return gif.Decode(r)
 		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
+		func(w io.Writer, img image.Image, params map[string]string) error {
 			return png.Encode(w, img)
 		},
-	)
+	).WithAnimation(decodeGIFAnimation, nil)
 }
@@ -1,9 +1,8 @@
 package converter
 
 import (
-	"bytes"
 	"image"
-	"image/jpeg"
+	"io"
 
 	"github.com/jdeng/goheif"
 	"github.com/wukong-app/ruyi/pkg/contract"
@@ -13,12 +12,12 @@ func NewHEICToJPEGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.HEIC(),
 		contract.JPEG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		autoOrient(func(r io.Reader, params map[string]string) (image.Image, error) {
 			return goheif.Decode(r)
-		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
-			return jpeg.Encode(w, img, &jpeg.Options{Quality: ParseQualityParam(params)})
-		},
+		}, heicOrientation),
+		encodeJPEG,
 		NewQualityParam(),
+		NewFlattenBackgroundParam(),
+		NewAutoOrientParam(),
 	)
 }
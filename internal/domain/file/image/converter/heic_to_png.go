@@ -1,9 +1,9 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/png"
+	"io"
 
 	"github.com/jdeng/goheif"
 	"github.com/wukong-app/ruyi/pkg/contract"
@@ -13,11 +13,12 @@ func NewHEICToPNGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.HEIC(),
 		contract.PNG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		autoOrient(func(r io.Reader, params map[string]string) (image.Image, error) {
 			return goheif.Decode(r)
-		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
+		}, heicOrientation),
+		func(w io.Writer, img image.Image, params map[string]string) error {
 			return png.Encode(w, img)
 		},
+		NewAutoOrientParam(),
 	)
 }
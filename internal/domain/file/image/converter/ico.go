@@ -0,0 +1,300 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/biessek/golang-ico"
+	"github.com/disintegration/imaging"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// ICO 文件头 (ICONDIR) 与目录项 (ICONDIRENTRY) 的长度
+const (
+	icoHeaderSize = 6
+	icoEntrySize  = 16
+)
+
+// icoMaxSize ICO 单个图像的最大边长
+const icoMaxSize = 256
+
+// icoEntry ICO 中的一个图像：实际尺寸、原始目录项与图像数据（PNG 或不含文件头的 BMP）
+type icoEntry struct {
+	width, height int
+	dirEntry      []byte
+	data          []byte
+}
+
+// readICOEntries 读取 ICO 目录中的全部图像，尺寸取自图像数据本身（PNG 的 IHDR、BMP 的信息头）
+func readICOEntries(in []byte) ([]icoEntry, error) {
+	if len(in) < icoHeaderSize || binary.LittleEndian.Uint16(in[0:]) != 0 || binary.LittleEndian.Uint16(in[2:]) != 1 {
+		return nil, exception.Errorf("not an ico image")
+	}
+	count := int(binary.LittleEndian.Uint16(in[4:]))
+	if count == 0 {
+		return nil, exception.Errorf("ico has no images")
+	}
+
+	entries := make([]icoEntry, 0, count)
+	for i := 0; i < count; i++ {
+		offset := icoHeaderSize + i*icoEntrySize
+		if offset+icoEntrySize > len(in) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		dirEntry := in[offset : offset+icoEntrySize]
+		size := int64(binary.LittleEndian.Uint32(dirEntry[8:]))
+		start := int64(binary.LittleEndian.Uint32(dirEntry[12:]))
+		if start+size > int64(len(in)) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		entry := icoEntry{dirEntry: dirEntry, data: in[start : start+size]}
+
+		if bytes.HasPrefix(entry.data, []byte(pngSignature)) {
+			config, err := png.DecodeConfig(bytes.NewReader(entry.data))
+			if err != nil {
+				return nil, err
+			}
+			entry.width, entry.height = config.Width, config.Height
+		} else {
+			// BMP 信息头：biSize、biWidth、biHeight（包含 AND 掩码，为实际高度的两倍）
+			if len(entry.data) < 12 {
+				return nil, exception.Errorf("invalid bmp data of ico image %d", i)
+			}
+			width, height := int32(binary.LittleEndian.Uint32(entry.data[4:])), int32(binary.LittleEndian.Uint32(entry.data[8:]))
+			entry.width, entry.height = int(max(width, -width)), int(max(height, -height))/2
+		}
+		if entry.width <= 0 || entry.height <= 0 {
+			return nil, exception.Errorf("invalid size of ico image %d", i)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// largestICOEntry 返回像素数最大的图像下标，相同时取靠前的
+func largestICOEntry(entries []icoEntry) int {
+	largest := 0
+	for i, entry := range entries {
+		if entry.width*entry.height > entries[largest].width*entries[largest].height {
+			largest = i
+		}
+	}
+	return largest
+}
+
+// decodeICOConfig 读取 ICO 目录，校验每个图像的像素数，返回最大图像的尺寸
+func decodeICOConfig(r io.Reader, limits contract.Limits) (image.Config, error) {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	entries, err := readICOEntries(in)
+	if err != nil {
+		return image.Config{}, err
+	}
+	for _, entry := range entries {
+		if err = limits.CheckPixels(entry.width, entry.height); err != nil {
+			return image.Config{}, err
+		}
+	}
+	largest := entries[largestICOEntry(entries)]
+	return image.Config{ColorModel: color.NRGBAModel, Width: largest.width, Height: largest.height}, nil
+}
+
+// selectICOEntry 按 index、size 参数选取图像
+//
+// 说明:
+//
+//	index 优先；size 选取边长等于 size 的图像，没有时选取不小于 size 的最小图像，均小于 size 时选取最大图像；
+//	两者均未指定时选取最大图像。
+func selectICOEntry(entries []icoEntry, params map[string]string) (int, error) {
+	if value := params[ParamIndex]; value != "" {
+		index, _ := strconv.Atoi(value)
+		if index >= len(entries) {
+			return 0, &contract.ParamError{
+				Reason: contract.ParamInvalid,
+				Name:   ParamIndex,
+				Value:  value,
+				Err:    exception.Errorf("index out of range, the icon has %d images", len(entries)),
+			}
+		}
+		return index, nil
+	}
+
+	largest := largestICOEntry(entries)
+	size, _ := strconv.Atoi(params[ParamSize])
+	if size <= 0 {
+		return largest, nil
+	}
+	best := -1
+	for i, entry := range entries {
+		edge := max(entry.width, entry.height)
+		if edge < size {
+			continue
+		}
+		if best < 0 || edge < max(entries[best].width, entries[best].height) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return largest, nil
+	}
+	return best, nil
+}
+
+// decodeICO 按 index、size 参数解码 ICO 中的一个图像（见 selectICOEntry）
+func decodeICO(r io.Reader, params map[string]string) (image.Image, error) {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := readICOEntries(in)
+	if err != nil {
+		return nil, err
+	}
+	i, err := selectICOEntry(entries, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := entries[i]
+	if bytes.HasPrefix(entry.data, []byte(pngSignature)) {
+		return png.Decode(bytes.NewReader(entry.data))
+	}
+	// BMP 图像组装为只有该图像的 ICO，由 golang-ico 处理 AND 掩码与 32 位 alpha
+	single := make([]byte, 0, icoHeaderSize+icoEntrySize+len(entry.data))
+	single = append(single, 0, 0, 1, 0, 1, 0)
+	single = append(single, entry.dirEntry[:12]...)
+	single = binary.LittleEndian.AppendUint32(single, icoHeaderSize+icoEntrySize)
+	single = append(single, entry.data...)
+	return ico.Decode(bytes.NewReader(single))
+}
+
+// parseICOSizes 解析 sizes 参数：逗号分隔的边长列表（1-256），去重后从小到大排列
+func parseICOSizes(value string) ([]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	seen := make(map[int]bool)
+	var sizes []int
+	for _, part := range strings.Split(value, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || size < 1 || size > icoMaxSize {
+			return nil, exception.Errorf("param value must be comma separated sizes between 1 and %d, e.g. 16,32,48,256", icoMaxSize)
+		}
+		if !seen[size] {
+			seen[size] = true
+			sizes = append(sizes, size)
+		}
+	}
+	sort.Ints(sizes)
+	return sizes, nil
+}
+
+// icoRasterParams SVG 光栅化参数：指定了 sizes 时按最大边长光栅化，见 squareRasterParams
+func icoRasterParams(params map[string]string) map[string]string {
+	sizes, _ := parseICOSizes(params[ParamSizes])
+	if len(sizes) == 0 {
+		return params
+	}
+	return squareRasterParams(params, sizes[len(sizes)-1])
+}
+
+// squareRasterParams 未指定宽高时，返回将 SVG 等比光栅化到 edge x edge 框内的参数，避免先光栅化为小图再放大造成的模糊
+func squareRasterParams(params map[string]string, edge int) map[string]string {
+	opts := ParseResizeOptions(params)
+	if opts.Width > 0 || opts.Height > 0 {
+		return params
+	}
+	raster := make(map[string]string, len(params))
+	for k, v := range params {
+		raster[k] = v
+	}
+	raster[ParamWidth], raster[ParamHeight], raster[ParamResizeMode] = strconv.Itoa(edge), strconv.Itoa(edge), string(ResizeFit)
+	return raster
+}
+
+// decodeSVGForICO 解码 SVG，光栅化尺寸见 icoRasterParams
+func decodeSVGForICO(r io.Reader, params map[string]string) (image.Image, error) {
+	return decodeSVG(r, icoRasterParams(params))
+}
+
+// squareIcon 将图片等比缩放到 size x size 画布内并居中，四周各留出 padding（边长的比例）的空白，空白透明
+func squareIcon(img image.Image, size int, padding float64, filter imaging.ResampleFilter) *image.NRGBA {
+	bounds := img.Bounds()
+	content := float64(size) * (1 - 2*padding)
+	scale := content / float64(max(bounds.Dx(), bounds.Dy()))
+	width, height := max(1, int(float64(bounds.Dx())*scale+0.5)), max(1, int(float64(bounds.Dy())*scale+0.5))
+	resized := imaging.Resize(img, width, height, filter)
+	canvas := image.NewNRGBA(image.Rect(0, 0, size, size))
+	at := image.Pt((size-width)/2, (size-height)/2)
+	draw.Draw(canvas, resized.Bounds().Add(at), resized, image.Point{}, draw.Src)
+	return canvas
+}
+
+// encodeICO 编码为 ICO，每个图像均以 PNG 格式存储
+//
+// 说明:
+//
+//	未指定 sizes 时输出一个与图片尺寸相同的图像；
+//	否则按每个边长将图片等比缩放到正方形画布内（居中，空白透明），使用 filter 参数指定的重采样滤镜。
+func encodeICO(w io.Writer, img image.Image, params map[string]string) error {
+	sizes, err := parseICOSizes(params[ParamSizes])
+	if err != nil {
+		return err
+	}
+
+	var images []image.Image
+	if len(sizes) == 0 {
+		images = append(images, img)
+	}
+	filter := resampleFilter(ParseResizeOptions(params).Filter)
+	for _, size := range sizes {
+		images = append(images, squareIcon(img, size, 0, filter))
+	}
+	return writeICO(w, images)
+}
+
+// writeICO 将多个图像写为 ICO，每个图像均以 PNG 格式存储
+func writeICO(w io.Writer, images []image.Image) error {
+	header := make([]byte, 0, icoHeaderSize+icoEntrySize*len(images))
+	header = append(header, 0, 0, 1, 0)
+	header = binary.LittleEndian.AppendUint16(header, uint16(len(images)))
+	var data bytes.Buffer
+	for _, m := range images {
+		offset := icoHeaderSize + icoEntrySize*len(images) + data.Len()
+		size := data.Len()
+		if err := png.Encode(&data, m); err != nil {
+			return err
+		}
+		b := m.Bounds()
+		header = append(header, icoDimension(b.Dx()), icoDimension(b.Dy()), 0, 0) // 宽、高、调色板颜色数、保留
+		header = binary.LittleEndian.AppendUint16(header, 1)                      // 颜色平面数
+		header = binary.LittleEndian.AppendUint16(header, 32)                     // 位深
+		header = binary.LittleEndian.AppendUint32(header, uint32(data.Len()-size))
+		header = binary.LittleEndian.AppendUint32(header, uint32(offset))
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(data.Bytes())
+	return err
+}
+
+// icoDimension 目录项中的宽、高：0 表示 256 及以上
+func icoDimension(v int) byte {
+	if v >= icoMaxSize {
+		return 0
+	}
+	return byte(v)
+}
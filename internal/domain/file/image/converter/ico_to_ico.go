@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewICOToICOConverter ICO -> ICO 重新编码转换器，用于缩放、图片处理：从源图选取一个图像（见 size、index），按 sizes 重新生成图标
+func NewICOToICOConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.ICO(),
+		contract.ICO(),
+		decodeICO,
+		encodeICO,
+		NewICOSizeParam(),
+		NewICOIndexParam(),
+		NewICOSizesParam(),
+	).WithDecodeConfig(decodeICOConfig)
+}
@@ -1,11 +1,10 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/png"
+	"io"
 
-	"github.com/biessek/golang-ico"
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
 
@@ -13,11 +12,10 @@ func NewICOToPNGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.ICO(),
 		contract.PNG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
-			return ico.Decode(r)
-		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
+		decodeICO,
+		func(w io.Writer, img image.Image, params map[string]string) error {
 			return png.Encode(w, img)
 		},
-	)
+		NewICOSelectParams()...,
+	).WithDecodeConfig(decodeICOConfig)
 }
@@ -0,0 +1,187 @@
+package converter
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// iconBundleRasterSize 图标包中最大图标的边长，SVG 按该尺寸光栅化
+const iconBundleRasterSize = 512
+
+// 图标包中的文件
+const (
+	iconBundleFavicon     = "favicon.ico"
+	iconBundleManifest    = "site.webmanifest"
+	iconBundleHTMLSnippet = "icons.html"
+)
+
+// iconBundleFaviconSizes favicon.ico 包含的图像边长
+var iconBundleFaviconSizes = []int{16, 32, 48}
+
+// bundleIcon 图标包中的一个 PNG 图标
+type bundleIcon struct {
+	name     string // 文件名
+	size     int    // 边长
+	opaque   bool   // 是否铺在背景色上（apple-touch-icon、maskable 图标不应透明）
+	maskable bool   // 是否为 maskable 图标：四周留出安全区
+	manifest bool   // 是否写入 site.webmanifest
+}
+
+// bundleIcons 图标包中的 PNG 图标
+var bundleIcons = []bundleIcon{
+	{name: "favicon-16x16.png", size: 16},
+	{name: "favicon-32x32.png", size: 32},
+	{name: "apple-touch-icon.png", size: 180, opaque: true},
+	{name: "android-chrome-192x192.png", size: 192, manifest: true},
+	{name: "android-chrome-512x512.png", size: 512, manifest: true},
+	{name: "maskable-icon-192x192.png", size: 192, opaque: true, maskable: true, manifest: true},
+	{name: "maskable-icon-512x512.png", size: 512, opaque: true, maskable: true, manifest: true},
+}
+
+// webManifest site.webmanifest 的内容
+type webManifest struct {
+	Name            string                `json:"name,omitempty"`
+	ShortName       string                `json:"short_name,omitempty"`
+	Icons           []webManifestIconSpec `json:"icons"`
+	ThemeColor      string                `json:"theme_color"`
+	BackgroundColor string                `json:"background_color"`
+	Display         string                `json:"display"`
+}
+
+// webManifestIconSpec site.webmanifest 中的图标
+type webManifestIconSpec struct {
+	Src     string `json:"src"`
+	Sizes   string `json:"sizes"`
+	Type    string `json:"type"`
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// decodeSVGForIconBundle 解码 SVG，未指定宽高时按图标包中最大图标的边长光栅化
+func decodeSVGForIconBundle(r io.Reader, params map[string]string) (image.Image, error) {
+	return decodeSVG(r, squareRasterParams(params, iconBundleRasterSize))
+}
+
+// encodeIconBundle 由缩放、处理后的图片生成图标包，打包为 ZIP
+//
+// 说明:
+//
+//	包含 favicon.ico（16、32、48）、favicon PNG、apple-touch-icon、Android 与 maskable 图标、
+//	site.webmanifest，以及可直接粘贴到 <head> 中的 HTML 片段 icons.html。
+//	每个图标都由该图片等比缩放到正方形画布内，使用 filter 参数指定的重采样滤镜。
+func encodeIconBundle(w io.Writer, img image.Image, params map[string]string) error {
+	var (
+		filter     = resampleFilter(ParseResizeOptions(params).Filter)
+		background = ParseBackground(params)
+		padding, _ = strconv.ParseFloat(params[ParamMaskablePadding], 64)
+		path       = params[ParamIconPath]
+		zw         = zip.NewWriter(w)
+	)
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	// favicon.ico
+	favicons := make([]image.Image, 0, len(iconBundleFaviconSizes))
+	for _, size := range iconBundleFaviconSizes {
+		favicons = append(favicons, squareIcon(img, size, 0, filter))
+	}
+	entry, err := zw.Create(iconBundleFavicon)
+	if err != nil {
+		return err
+	}
+	if err = writeICO(entry, favicons); err != nil {
+		return err
+	}
+
+	// PNG 图标
+	manifest := webManifest{
+		Name:            params[ParamAppName],
+		ShortName:       params[ParamAppName],
+		ThemeColor:      hexColor(parseColorParam(params[ParamThemeColor])),
+		BackgroundColor: hexColor(background),
+		Display:         "standalone",
+	}
+	for _, icon := range bundleIcons {
+		pad := 0.0
+		if icon.maskable {
+			pad = padding / 100
+		}
+		var m image.Image = squareIcon(img, icon.size, pad, filter)
+		if icon.opaque {
+			m = flatten(m, background)
+		}
+		if entry, err = zw.Create(icon.name); err != nil {
+			return err
+		}
+		if err = png.Encode(entry, m); err != nil {
+			return err
+		}
+
+		if icon.manifest {
+			spec := webManifestIconSpec{Src: path + icon.name, Sizes: fmt.Sprintf("%dx%d", icon.size, icon.size), Type: "image/png"}
+			if icon.maskable {
+				spec.Purpose = "maskable"
+			}
+			manifest.Icons = append(manifest.Icons, spec)
+		}
+	}
+
+	// site.webmanifest
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if entry, err = zw.Create(iconBundleManifest); err != nil {
+		return err
+	}
+	if _, err = entry.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	// HTML 片段
+	if entry, err = zw.Create(iconBundleHTMLSnippet); err != nil {
+		return err
+	}
+	if _, err = io.WriteString(entry, iconBundleHTML(path, manifest.ThemeColor)); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// iconBundleHTML 生成引用图标包的 HTML 片段
+func iconBundleHTML(path, themeColor string) string {
+	href := func(name string) string {
+		return html.EscapeString(path + name)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "<link rel=\"icon\" href=\"%s\" sizes=\"any\">\n", href(iconBundleFavicon))
+	fmt.Fprintf(&b, "<link rel=\"icon\" type=\"image/png\" sizes=\"32x32\" href=\"%s\">\n", href("favicon-32x32.png"))
+	fmt.Fprintf(&b, "<link rel=\"icon\" type=\"image/png\" sizes=\"16x16\" href=\"%s\">\n", href("favicon-16x16.png"))
+	fmt.Fprintf(&b, "<link rel=\"apple-touch-icon\" sizes=\"180x180\" href=\"%s\">\n", href("apple-touch-icon.png"))
+	fmt.Fprintf(&b, "<link rel=\"manifest\" href=\"%s\">\n", href(iconBundleManifest))
+	fmt.Fprintf(&b, "<meta name=\"theme-color\" content=\"%s\">\n", themeColor)
+	return b.String()
+}
+
+// parseColorParam 解析颜色参数（参数应已通过校验），非法时返回白色
+func parseColorParam(value string) color.NRGBA {
+	if c, err := contract.ParseColor(value); err == nil {
+		return c
+	}
+	return color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+}
+
+// hexColor 将颜色格式化为 #rrggbb，忽略透明度
+func hexColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"image"
+	"image/jpeg"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewJPEGToICOConverter JPEG -> ICO 转换器，通过 sizes 参数生成包含多个尺寸的图标
+func NewJPEGToICOConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.JPEG(),
+		contract.ICO(),
+		autoOrient(func(r io.Reader, params map[string]string) (image.Image, error) {
+			return jpeg.Decode(r)
+		}, jpegOrientation),
+		encodeICO,
+		NewAutoOrientParam(),
+		NewICOSizesParam(),
+	)
+}
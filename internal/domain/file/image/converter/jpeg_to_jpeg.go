@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"image"
+	"image/jpeg"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewJPEGToJPEGConverter JPEG -> JPEG 重新编码转换器，用于缩放、压缩（如生成缩略图），重新编码会移除 EXIF、ICC 等元数据
+func NewJPEGToJPEGConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.JPEG(),
+		contract.JPEG(),
+		autoOrient(func(r io.Reader, params map[string]string) (image.Image, error) {
+			return jpeg.Decode(r)
+		}, jpegOrientation),
+		encodeJPEG,
+		NewQualityParam(),
+		NewFlattenBackgroundParam(),
+		NewAutoOrientParam(),
+	)
+}
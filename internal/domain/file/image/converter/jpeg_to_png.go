@@ -1,10 +1,10 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/jpeg"
 	"image/png"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
@@ -13,11 +13,12 @@ func NewJPEGToPNGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.JPEG(),
 		contract.PNG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		autoOrient(func(r io.Reader, params map[string]string) (image.Image, error) {
 			return jpeg.Decode(r)
-		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
+		}, jpegOrientation),
+		func(w io.Writer, img image.Image, params map[string]string) error {
 			return png.Encode(w, img)
 		},
+		NewAutoOrientParam(),
 	)
 }
@@ -0,0 +1,129 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"image"
+	"io"
+	"math"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// readDecodeConfig 解码前读取图片头
+//
+// 说明:
+//
+//	读取图片头消费的数据会被缓存，返回的 io.Reader 从头重放完整输入，可直接用于解码，并保留 r 所属转换的 ctx（见 readerContext）。
+//	decodeConfig 可以通过 cacheDecoded 缓存解析结果，解码时由 cachedDecoded 取回，避免重复解析。
+func readDecodeConfig(r io.Reader, limits contract.Limits, decodeConfig DecodeConfigFunc) (io.Reader, image.Config, error) {
+	var (
+		head  bytes.Buffer
+		cache = &decodeCache{}
+	)
+	config, err := decodeConfig(&configReader{Reader: io.TeeReader(r, &head), cache: cache}, limits)
+	if err != nil {
+		return nil, image.Config{}, err
+	}
+	return &replayReader{Reader: io.MultiReader(&head, r), ctx: readerContext(r), cache: cache}, config, nil
+}
+
+// decodeCache 读取图片头时缓存的解析结果（如 SVG 的 oksvg.SvgIcon）
+type decodeCache struct {
+	value any
+}
+
+// configReader 传给 DecodeConfigFunc 的 Reader
+type configReader struct {
+	io.Reader
+	cache *decodeCache
+}
+
+// replayReader 重放完整输入的 Reader
+type replayReader struct {
+	io.Reader
+	ctx   context.Context
+	cache *decodeCache
+}
+
+// Context 返回读取所属转换的 ctx
+func (r *replayReader) Context() context.Context {
+	return r.ctx
+}
+
+// cacheDecoded 读取图片头时缓存解析结果，r 不是 readDecodeConfig 传入的 Reader 时忽略
+func cacheDecoded(r io.Reader, value any) {
+	if cr, ok := r.(*configReader); ok {
+		cr.cache.value = value
+	}
+}
+
+// cachedDecoded 取回读取图片头时缓存的解析结果，没有时返回 nil
+func cachedDecoded(r io.Reader) any {
+	if rr, ok := r.(*replayReader); ok {
+		return rr.cache.value
+	}
+	return nil
+}
+
+// decodeRasterConfig 使用 image.DecodeConfig 读取位图头，校验声明的像素数，防御解压炸弹
+func decodeRasterConfig(r io.Reader, limits contract.Limits) (image.Config, error) {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	return config, limits.CheckPixels(config.Width, config.Height)
+}
+
+// checkOutputSize 校验 srcW x srcH 的源图经过几何变换、缩放后，缩放中间结果与最终输出的尺寸
+func checkOutputSize(limits contract.Limits, opts ResizeOptions, ops Operations, srcW, srcH int) error {
+	srcW, srcH = ops.TransformedSize(srcW, srcH)
+	if err := limits.CheckOutputSize(opts.ScaledSize(srcW, srcH)); err != nil {
+		return err
+	}
+	return limits.CheckOutputSize(opts.Size(srcW, srcH))
+}
+
+// resizedSize 计算缩放后的尺寸，规则与 imaging.Resize 一致：宽高之一为 0 时按原比例计算
+func resizedSize(srcW, srcH int, width, height int64) (int, int) {
+	switch {
+	case width > 0 && height > 0:
+		return int(width), int(height)
+	case width > 0 && srcW > 0:
+		return int(width), max(1, int(math.Round(float64(srcH)*float64(width)/float64(srcW))))
+	case height > 0 && srcH > 0:
+		return max(1, int(math.Round(float64(srcW)*float64(height)/float64(srcH)))), int(height)
+	default:
+		return srcW, srcH
+	}
+}
+
+// checkSVGElements 在解析 SVG 之前统计元素个数，超出限制时立即返回
+//
+// 说明:
+//
+//	XML 本身不合法时返回 nil，交由 SVG 解析器报告解码错误。
+func checkSVGElements(in []byte, limits contract.Limits) error {
+	if limits.MaxSVGElements <= 0 {
+		return nil
+	}
+
+	var (
+		decoder = xml.NewDecoder(bytes.NewReader(in))
+		count   int
+	)
+	decoder.Strict = false
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return nil
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			count++
+			if err = limits.CheckSVGElements(count); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package converter
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// Flip 翻转方向
+type Flip string
+
+const (
+	FlipNone       Flip = "none"       // 不翻转（默认）
+	FlipHorizontal Flip = "horizontal" // 水平翻转（左右镜像）
+	FlipVertical   Flip = "vertical"   // 垂直翻转（上下镜像）
+	FlipBoth       Flip = "both"       // 水平、垂直同时翻转
+)
+
+// Operations 图片处理操作，由 ParseOperations 从转换器参数解析
+//
+// 说明:
+//
+//	BaseConverter 在解码与编码之间按以下顺序执行：
+//	Transform（crop -> rotate -> flip） -> 缩放 -> Adjust（grayscale -> brightness -> contrast -> gamma -> blur -> sharpen）。
+//	几何变换在缩放之前执行，因此 crop 使用源图坐标，width、height 约束的是变换后的图片；
+//	色彩调整与滤镜在缩放之后执行，处理的像素更少。
+type Operations struct {
+	Crop       image.Rectangle // 裁剪区域（源图坐标），空表示不裁剪
+	Rotate     float64         // 顺时针旋转角度，范围 [0, 360)
+	Background color.NRGBA     // 任意角度旋转后四角空白区域的背景色
+	Flip       Flip            // 翻转方向
+	Grayscale  bool            // 是否转为灰度
+	Brightness float64         // 亮度调整百分比，范围 [-100, 100]
+	Contrast   float64         // 对比度调整百分比，范围 [-100, 100]
+	Gamma      float64         // gamma 校正，1 表示不调整
+	Blur       float64         // 高斯模糊 sigma，0 表示不模糊
+	Sharpen    float64         // 锐化 sigma，0 表示不锐化
+}
+
+// ParseOperations 解析图片处理相关参数（参数应已通过校验）
+func ParseOperations(params map[string]string) Operations {
+	ops := Operations{
+		Flip:  Flip(strings.ToLower(params[ParamFlip])),
+		Gamma: 1,
+	}
+	ops.Crop, _ = parseCrop(params[ParamCrop])
+	if v, err := strconv.ParseFloat(params[ParamRotate], 64); err == nil {
+		ops.Rotate = math.Mod(math.Mod(v, 360)+360, 360)
+	}
+	if params[ParamBackground] != "" {
+		ops.Background, _ = contract.ParseColor(params[ParamBackground])
+	}
+	if ops.Flip == "" {
+		ops.Flip = FlipNone
+	}
+	ops.Grayscale, _ = strconv.ParseBool(params[ParamGrayscale])
+	ops.Brightness, _ = strconv.ParseFloat(params[ParamBrightness], 64)
+	ops.Contrast, _ = strconv.ParseFloat(params[ParamContrast], 64)
+	if v, err := strconv.ParseFloat(params[ParamGamma], 64); err == nil && v > 0 {
+		ops.Gamma = v
+	}
+	ops.Blur, _ = strconv.ParseFloat(params[ParamBlur], 64)
+	ops.Sharpen, _ = strconv.ParseFloat(params[ParamSharpen], 64)
+	return ops
+}
+
+// parseCrop 解析裁剪区域，格式为 x,y,width,height（单位：像素），空字符串表示不裁剪
+func parseCrop(value string) (image.Rectangle, error) {
+	if value == "" {
+		return image.Rectangle{}, nil
+	}
+	fields := strings.Split(value, ",")
+	if len(fields) != 4 {
+		return image.Rectangle{}, exception.Errorf("param value must be x,y,width,height")
+	}
+	var v [4]int
+	for i, field := range fields {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 0 {
+			return image.Rectangle{}, exception.Errorf("param value must be x,y,width,height of non-negative integers")
+		}
+		v[i] = n
+	}
+	if v[2] == 0 || v[3] == 0 {
+		return image.Rectangle{}, exception.Errorf("crop width and height must be positive")
+	}
+	return image.Rect(v[0], v[1], v[0]+v[2], v[1]+v[3]), nil
+}
+
+// Geometric 是否包含改变图片尺寸的几何变换（crop、rotate）
+func (o Operations) Geometric() bool {
+	return !o.Crop.Empty() || o.Rotate != 0
+}
+
+// TransformedSize 计算 srcW x srcH 的图片经过 Transform 后的尺寸，用于解码前校验资源限制
+func (o Operations) TransformedSize(srcW, srcH int) (int, int) {
+	w, h := srcW, srcH
+	if !o.Crop.Empty() {
+		if crop := o.Crop.Intersect(image.Rect(0, 0, srcW, srcH)); !crop.Empty() {
+			w, h = crop.Dx(), crop.Dy()
+		}
+	}
+	switch o.Rotate {
+	case 0, 180:
+		return w, h
+	case 90, 270:
+		return h, w
+	default:
+		sin, cos := math.Sincos(o.Rotate * math.Pi / 180)
+		return int(math.Ceil(float64(w)*math.Abs(cos) + float64(h)*math.Abs(sin))),
+			int(math.Ceil(float64(w)*math.Abs(sin) + float64(h)*math.Abs(cos)))
+	}
+}
+
+// Transform 依次执行裁剪、旋转、翻转，每一步之前检查 ctx
+//
+// 说明:
+//
+//	裁剪区域超出图片时按图片边界截取；与图片完全不相交时返回 crop 参数错误。
+func (o Operations) Transform(ctx context.Context, img image.Image) (image.Image, error) {
+	if !o.Crop.Empty() {
+		bounds := img.Bounds()
+		crop := o.Crop.Add(bounds.Min).Intersect(bounds)
+		if crop.Empty() {
+			return nil, &contract.ParamError{
+				Reason: contract.ParamInvalid,
+				Name:   ParamCrop,
+				Value:  formatCrop(o.Crop),
+				Err:    exception.Errorf("crop area is outside the %dx%d image", bounds.Dx(), bounds.Dy()),
+			}
+		}
+		img = imaging.Crop(img, crop)
+	}
+
+	// imaging 的旋转角度为逆时针
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	switch o.Rotate {
+	case 0:
+	case 90:
+		img = imaging.Rotate270(img)
+	case 180:
+		img = imaging.Rotate180(img)
+	case 270:
+		img = imaging.Rotate90(img)
+	default:
+		img = imaging.Rotate(img, 360-o.Rotate, o.Background)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	switch o.Flip {
+	case FlipHorizontal:
+		img = imaging.FlipH(img)
+	case FlipVertical:
+		img = imaging.FlipV(img)
+	case FlipBoth:
+		img = imaging.Rotate180(img)
+	}
+	return img, nil
+}
+
+// Adjust 依次执行灰度、亮度、对比度、gamma、模糊、锐化，没有需要执行的操作时原样返回
+//
+// 说明:
+//
+//	每个操作都按行分带执行（见 inRowBands），模糊、锐化每带多取高斯核半径的邻域行，结果与整张图片一次处理相同。
+//	ctx 结束时返回 ctx 的错误。
+func (o Operations) Adjust(ctx context.Context, img *image.NRGBA) (*image.NRGBA, error) {
+	steps := []struct {
+		enabled bool
+		margin  int
+		fn      func(band image.Image) *image.NRGBA
+	}{
+		{o.Grayscale, 0, imaging.Grayscale},
+		{o.Brightness != 0, 0, func(band image.Image) *image.NRGBA { return imaging.AdjustBrightness(band, o.Brightness) }},
+		{o.Contrast != 0, 0, func(band image.Image) *image.NRGBA { return imaging.AdjustContrast(band, o.Contrast) }},
+		{o.Gamma != 1, 0, func(band image.Image) *image.NRGBA { return imaging.AdjustGamma(band, o.Gamma) }},
+		{o.Blur > 0, blurRadius(o.Blur), func(band image.Image) *image.NRGBA { return imaging.Blur(band, o.Blur) }},
+		{o.Sharpen > 0, blurRadius(o.Sharpen), func(band image.Image) *image.NRGBA { return imaging.Sharpen(band, o.Sharpen) }},
+	}
+	for _, step := range steps {
+		if !step.enabled {
+			continue
+		}
+		out, err := inRowBands(ctx, img, img.Bounds().Dx(), step.margin, step.fn)
+		if err != nil {
+			return nil, err
+		}
+		img = out
+	}
+	return img, nil
+}
+
+// blurRadius imaging 高斯核的半径
+func blurRadius(sigma float64) int {
+	return int(math.Ceil(sigma * 3))
+}
+
+// formatCrop 将裁剪区域格式化为参数值
+func formatCrop(r image.Rectangle) string {
+	return strings.Join([]string{
+		strconv.Itoa(r.Min.X), strconv.Itoa(r.Min.Y), strconv.Itoa(r.Dx()), strconv.Itoa(r.Dy()),
+	}, ",")
+}
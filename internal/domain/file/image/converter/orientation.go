@@ -0,0 +1,153 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"io"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+	"github.com/jdeng/goheif"
+)
+
+// OrientationFunc 定义从完整输入中读取 EXIF Orientation 的函数签名，无法读取时返回 1（正常方向）
+type OrientationFunc func(in []byte) int
+
+// exifOrientationTag EXIF Orientation 标签 ID
+const exifOrientationTag = 0x0112
+
+// autoOrient 包装解码函数：auto_orient 参数为 true 时读取 EXIF Orientation，解码后旋转、翻转为正常方向
+//
+// 说明:
+//
+//	读取 EXIF 需要完整输入（HEIC 的 EXIF 可能位于文件末尾），因此会先将输入读入内存。
+func autoOrient(decode DecodeFunc, orientation OrientationFunc) DecodeFunc {
+	return func(r io.Reader, params map[string]string) (image.Image, error) {
+		if enabled, _ := strconv.ParseBool(params[ParamAutoOrient]); !enabled {
+			return decode(r, params)
+		}
+
+		in, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		img, err := decode(bytes.NewReader(in), params)
+		if err != nil {
+			return nil, err
+		}
+		return orient(img, orientation(in)), nil
+	}
+}
+
+// orient 按 EXIF Orientation 将图片旋转、翻转为正常方向
+func orient(img image.Image, orientation int) image.Image {
+	switch orientation {
+	case 2:
+		return imaging.FlipH(img)
+	case 3:
+		return imaging.Rotate180(img)
+	case 4:
+		return imaging.FlipV(img)
+	case 5:
+		return imaging.Transpose(img)
+	case 6:
+		return imaging.Rotate270(img)
+	case 7:
+		return imaging.Transverse(img)
+	case 8:
+		return imaging.Rotate90(img)
+	default:
+		return img
+	}
+}
+
+// jpegOrientation 读取 JPEG APP1 段中的 EXIF Orientation
+func jpegOrientation(in []byte) int {
+	if len(in) < 2 || in[0] != 0xFF || in[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(in); {
+		if in[i] != 0xFF {
+			return 1
+		}
+		marker := in[i+1]
+		switch {
+		case marker == 0xFF: // 填充字节
+			i++
+			continue
+		case marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01: // 无长度字段的标记
+			i += 2
+			continue
+		case marker == 0xDA || marker == 0xD9: // 图像数据开始，EXIF 只会出现在之前
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(in[i+2:]))
+		if size < 2 || i+2+size > len(in) {
+			return 1
+		}
+		segment := in[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+// heicOrientation 读取 HEIC Exif 元数据项中的 EXIF Orientation
+func heicOrientation(in []byte) int {
+	exif, err := goheif.ExtractExif(bytes.NewReader(in))
+	if err != nil {
+		return 1
+	}
+	// Exif 元数据项在 TIFF 头之前可能带有 "Exif\0\0" 标识
+	if i := bytes.Index(exif, []byte("Exif\x00\x00")); i >= 0 && i < 16 {
+		exif = exif[i+6:]
+	}
+	return tiffOrientation(exif)
+}
+
+// tiffOrientation 读取 TIFF 结构（TIFF 文件或 EXIF 数据）第一个 IFD 中的 Orientation 标签
+func tiffOrientation(in []byte) int {
+	if len(in) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(in[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(in[2:]) != 42 {
+		return 1
+	}
+
+	offset := int64(order.Uint32(in[4:]))
+	if offset+2 > int64(len(in)) {
+		return 1
+	}
+	count := int64(order.Uint16(in[offset:]))
+	for i := int64(0); i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > int64(len(in)) {
+			return 1
+		}
+		if order.Uint16(in[entry:]) != exifOrientationTag {
+			continue
+		}
+		// 类型应为 SHORT (3)，值直接存放在条目的值字段中
+		if order.Uint16(in[entry+2:]) != 3 {
+			return 1
+		}
+		if v := int(order.Uint16(in[entry+8:])); v >= 1 && v <= 8 {
+			return v
+		}
+		return 1
+	}
+	return 1
+}
@@ -1,7 +1,6 @@
 package converter
 
 import (
-	"math"
 	"strconv"
 
 	"github.com/wukong-app/ruyi/internal/core"
@@ -11,11 +10,106 @@ import (
 
 // CommonParams 定义了图片转换通用的参数名称
 const (
-	ParamWidth   = core.ParamWidth
-	ParamHeight  = core.ParamHeight
-	ParamQuality = core.ParamQuality
+	ParamWidth         = core.ParamWidth
+	ParamHeight        = core.ParamHeight
+	ParamQuality       = core.ParamQuality
+	ParamResizeMode    = core.ParamResizeMode
+	ParamGravity       = core.ParamGravity
+	ParamBackground    = core.ParamBackground
+	ParamFilter        = core.ParamFilter
+	ParamNoUpscale     = core.ParamNoUpscale
+	ParamAutoOrient    = core.ParamAutoOrient
+	ParamCrop          = core.ParamCrop
+	ParamRotate        = core.ParamRotate
+	ParamFlip          = core.ParamFlip
+	ParamGrayscale     = core.ParamGrayscale
+	ParamBrightness    = core.ParamBrightness
+	ParamContrast      = core.ParamContrast
+	ParamGamma         = core.ParamGamma
+	ParamBlur          = core.ParamBlur
+	ParamSharpen       = core.ParamSharpen
+	ParamNumColors     = core.ParamNumColors
+	ParamQuantizer     = core.ParamQuantizer
+	ParamDither        = core.ParamDither
+	ParamTransparency  = core.ParamTransparency
+	ParamFrame         = core.ParamFrame
+	ParamFrameMode     = core.ParamFrameMode
+	ParamSpriteColumns = core.ParamSpriteColumns
+	ParamSizes         = core.ParamSizes
+	ParamSize          = core.ParamSize
+	ParamIndex         = core.ParamIndex
+
+	ParamAppName         = core.ParamAppName
+	ParamThemeColor      = core.ParamThemeColor
+	ParamIconPath        = core.ParamIconPath
+	ParamMaskablePadding = core.ParamMaskablePadding
+)
+
+// 通用参数值规格
+var (
+	// positiveIntSchema 非负整数
+	positiveIntSchema = contract.ParamSchema{Type: contract.ParamTypeInt}.WithMin(0)
+	// pixelSchema 像素尺寸
+	pixelSchema = positiveIntSchema.WithUnit("px")
+	// qualitySchema 图片质量
+	qualitySchema = contract.ParamSchema{Type: contract.ParamTypeInt}.WithRange(1, 100)
+	// resizeModeSchema 缩放模式
+	resizeModeSchema = contract.ParamSchema{Type: contract.ParamTypeEnum}.
+				WithEnum(string(ResizeExact), string(ResizeFit), string(ResizeFill), string(ResizeCover), string(ResizePad))
+	// gravitySchema 锚点
+	gravitySchema = contract.ParamSchema{Type: contract.ParamTypeEnum}.WithEnum(
+		string(GravityCenter), string(GravityTop), string(GravityBottom), string(GravityLeft), string(GravityRight),
+		string(GravityTopLeft), string(GravityTopRight), string(GravityBottomLeft), string(GravityBottomRight),
+	)
+	// colorSchema 颜色
+	colorSchema = contract.ParamSchema{Type: contract.ParamTypeColor}
+	// filterSchema 重采样滤镜
+	filterSchema = contract.ParamSchema{Type: contract.ParamTypeEnum}.WithEnum(filterNames()...)
+	// boolSchema 布尔值
+	boolSchema = contract.ParamSchema{Type: contract.ParamTypeBool}
+	// rotateSchema 旋转角度
+	rotateSchema = contract.ParamSchema{Type: contract.ParamTypeFloat}.WithRange(-360, 360).WithUnit("deg")
+	// flipSchema 翻转方向
+	flipSchema = contract.ParamSchema{Type: contract.ParamTypeEnum}.
+			WithEnum(string(FlipNone), string(FlipHorizontal), string(FlipVertical), string(FlipBoth))
+	// percentSchema 调整百分比
+	percentSchema = contract.ParamSchema{Type: contract.ParamTypeFloat}.WithRange(-100, 100).WithUnit("%")
+	// gammaSchema gamma 校正
+	gammaSchema = contract.ParamSchema{Type: contract.ParamTypeFloat}.WithRange(0.1, 10)
+	// sigmaSchema 模糊、锐化强度
+	sigmaSchema = contract.ParamSchema{Type: contract.ParamTypeFloat}.WithRange(0, 100)
+	// numColorsSchema 调色板颜色数
+	numColorsSchema = contract.ParamSchema{Type: contract.ParamTypeInt}.WithRange(2, 256)
+	// quantizerSchema 颜色量化算法
+	quantizerSchema = contract.ParamSchema{Type: contract.ParamTypeEnum}.
+			WithEnum(string(QuantizerMedianCut), string(QuantizerOctree), string(QuantizerPlan9))
+	// ditherSchema 抖动算法
+	ditherSchema = contract.ParamSchema{Type: contract.ParamTypeEnum}.WithEnum(string(DitherNone), string(DitherFloydSteinberg))
+	// maskablePaddingSchema maskable 图标安全区留白
+	maskablePaddingSchema = contract.ParamSchema{Type: contract.ParamTypeFloat}.WithRange(0, 40).WithUnit("%")
+	// frameModeSchema 动画帧输出模式
+	frameModeSchema = contract.ParamSchema{Type: contract.ParamTypeEnum}.
+			WithEnum(string(FrameModeAuto), string(FrameModeSingle), string(FrameModeSprite))
 )
 
+// 由参数值规格生成的校验函数
+var (
+	// CheckPositiveInt 校验是否为正整数（包含 0）
+	CheckPositiveInt = positiveIntSchema.Check
+)
+
+// CheckQuality 校验图片质量 (1-100)，值不能为空
+//
+// 说明:
+//
+//	转换器参数中 quality 为空时使用默认值（见 contract.ConverterParams.CheckAndGetParams），不会调用该函数。
+func CheckQuality(value string) error {
+	if value == "" {
+		return exception.Errorf("param is required")
+	}
+	return qualitySchema.Check(value)
+}
+
 // NewWidthParam 创建宽度参数定义
 func NewWidthParam() contract.ConverterParam {
 	return contract.ConverterParam{
@@ -23,7 +117,7 @@ func NewWidthParam() contract.ConverterParam {
 		Desc:     "转换后的图片宽度，单位：像素。值为正整数，默认值为 0，表示不缩放。",
 		Default:  "0",
 		Required: false,
-		Check:    CheckPositiveInt,
+		Schema:   pixelSchema,
 	}
 }
 
@@ -34,7 +128,7 @@ func NewHeightParam() contract.ConverterParam {
 		Desc:     "转换后的图片高度，单位：像素。值为正整数，默认值为 0，表示不缩放。",
 		Default:  "0",
 		Required: false,
-		Check:    CheckPositiveInt,
+		Schema:   pixelSchema,
 	}
 }
 
@@ -45,38 +139,317 @@ func NewQualityParam() contract.ConverterParam {
 		Desc:     "将结果编码为 JPG 时的图片质量，范围从 1 到 100（含），越高越好。",
 		Default:  "100",
 		Required: false,
-		Check:    CheckQuality,
+		Schema:   qualitySchema,
 	}
 }
 
-// CheckPositiveInt 校验是否为正整数（包含 0）
-func CheckPositiveInt(value string) error {
-	if value == "" {
-		return nil
+// NewResizeModeParam 创建缩放模式参数定义
+func NewResizeModeParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamResizeMode,
+		Desc: "同时指定 width 和 height 时的缩放模式：exact-拉伸到指定宽高；fit-保持比例缩放到框内；" +
+			"cover-保持比例缩放到覆盖整个框（不裁剪）；fill-保持比例覆盖后按 gravity 裁剪；pad-保持比例缩放到框内后按 gravity 填充 background。",
+		Default:  string(ResizeExact),
+		Required: false,
+		Schema:   resizeModeSchema,
 	}
-	v, err := strconv.ParseUint(value, 10, 64)
-	if err != nil {
-		return exception.Wrapf(err, "param value must be a positive integer")
+}
+
+// NewGravityParam 创建锚点参数定义
+func NewGravityParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamGravity,
+		Desc:     "resize_mode 为 fill、pad 时的锚点，决定裁剪保留的区域或图片在画布上的位置。",
+		Default:  string(GravityCenter),
+		Required: false,
+		Schema:   gravitySchema,
 	}
-	if v >= math.MaxInt {
-		return exception.Errorf("param value must be less than %d", math.MaxInt)
+}
+
+// NewPadBackgroundParam 创建 pad 模式背景色参数定义
+func NewPadBackgroundParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamBackground,
+		Desc:     "resize_mode 为 pad 时的背景色，以及 rotate 为任意角度时四角空白区域的背景色，支持 #rrggbb、#rrggbbaa、rgb()、rgba() 与颜色名，默认透明。",
+		Default:  "transparent",
+		Required: false,
+		Schema:   colorSchema,
 	}
-	return nil
 }
 
-// CheckQuality 校验图片质量 (1-100)
-func CheckQuality(value string) error {
-	if value == "" {
-		return exception.Errorf("param is required")
+// NewFlattenBackgroundParam 创建铺底背景色参数定义，用于不支持透明的输出格式（如 JPEG），覆盖 NewPadBackgroundParam
+func NewFlattenBackgroundParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamBackground,
+		Desc: "输出格式不支持透明时，透明像素铺底使用的背景色，同时用于 pad 模式与任意角度旋转的空白区域。" +
+			"支持 #rrggbb、#rrggbbaa、rgb()、rgba() 与颜色名，带透明度时先与白色混合，默认白色。",
+		Default:  "white",
+		Required: false,
+		Schema:   colorSchema,
+	}
+}
+
+// NewFilterParam 创建重采样滤镜参数定义
+func NewFilterParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamFilter,
+		Desc: "缩放时使用的重采样滤镜：nearest 适用于像素画与图标，box、linear 速度快，适合批量生成缩略图；" +
+			"catmull-rom、lanczos 画质更好但更慢。",
+		Default:  string(FilterLanczos),
+		Required: false,
+		Schema:   filterSchema,
+	}
+}
+
+// NewNoUpscaleParam 创建禁止放大参数定义
+func NewNoUpscaleParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamNoUpscale,
+		Desc:     "为 true 时不放大图片：源图小于目标尺寸时保持原尺寸（pad 模式仍输出完整画布）；exact 模式下目标宽高按同一比例缩小，保持目标比例。",
+		Default:  "false",
+		Required: false,
+		Schema:   boolSchema,
+	}
+}
+
+// NewAutoOrientParam 创建自动旋转参数定义，用于携带 EXIF 方向信息的源格式（JPEG、TIFF、HEIC）
+func NewAutoOrientParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamAutoOrient,
+		Desc:     "为 true 时按 EXIF Orientation 将图片旋转、翻转为正常方向（在缩放之前执行），例如手机拍摄的竖版照片。",
+		Default:  "true",
+		Required: false,
+		Schema:   boolSchema,
+	}
+}
+
+// NewCropParam 创建裁剪参数定义
+func NewCropParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamCrop,
+		Desc:     "裁剪区域，格式为 x,y,width,height，单位：像素，坐标相对于源图左上角（SVG 为 viewBox）。在旋转、缩放之前执行，默认不裁剪。",
+		Default:  "",
+		Required: false,
+		Check: func(value string) error {
+			_, err := parseCrop(value)
+			return err
+		},
+	}
+}
+
+// NewRotateParam 创建旋转参数定义
+func NewRotateParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamRotate,
+		Desc:     "顺时针旋转角度，90、180、270 为无损旋转；其他角度会扩大画布，四角空白区域使用 background 填充。",
+		Default:  "0",
+		Required: false,
+		Schema:   rotateSchema,
+	}
+}
+
+// NewFlipParam 创建翻转参数定义
+func NewFlipParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamFlip,
+		Desc:     "翻转方向：none-不翻转；horizontal-水平翻转；vertical-垂直翻转；both-同时水平、垂直翻转。在旋转之后执行。",
+		Default:  string(FlipNone),
+		Required: false,
+		Schema:   flipSchema,
+	}
+}
+
+// NewGrayscaleParam 创建灰度参数定义
+func NewGrayscaleParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamGrayscale,
+		Desc:     "为 true 时将图片转为灰度。",
+		Default:  "false",
+		Required: false,
+		Schema:   boolSchema,
+	}
+}
+
+// NewBrightnessParam 创建亮度参数定义
+func NewBrightnessParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamBrightness,
+		Desc:     "亮度调整百分比，范围从 -100 到 100，0 表示不调整。",
+		Default:  "0",
+		Required: false,
+		Schema:   percentSchema,
+	}
+}
+
+// NewContrastParam 创建对比度参数定义
+func NewContrastParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamContrast,
+		Desc:     "对比度调整百分比，范围从 -100 到 100，0 表示不调整。",
+		Default:  "0",
+		Required: false,
+		Schema:   percentSchema,
+	}
+}
+
+// NewGammaParam 创建 gamma 校正参数定义
+func NewGammaParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamGamma,
+		Desc:     "gamma 校正，范围从 0.1 到 10，小于 1 变暗，大于 1 变亮，1 表示不调整。",
+		Default:  "1",
+		Required: false,
+		Schema:   gammaSchema,
+	}
+}
+
+// NewBlurParam 创建高斯模糊参数定义
+func NewBlurParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamBlur,
+		Desc:     "高斯模糊强度（sigma），范围从 0 到 100，0 表示不模糊。",
+		Default:  "0",
+		Required: false,
+		Schema:   sigmaSchema,
+	}
+}
+
+// NewSharpenParam 创建锐化参数定义
+func NewSharpenParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamSharpen,
+		Desc:     "锐化强度（sigma），范围从 0 到 100，0 表示不锐化。",
+		Default:  "0",
+		Required: false,
+		Schema:   sigmaSchema,
+	}
+}
+
+// NewOperationParams 创建图片处理操作相关的参数定义：crop、rotate、flip、grayscale、brightness、contrast、gamma、blur、sharpen
+func NewOperationParams() []contract.ConverterParam {
+	return []contract.ConverterParam{
+		NewCropParam(),
+		NewRotateParam(),
+		NewFlipParam(),
+		NewGrayscaleParam(),
+		NewBrightnessParam(),
+		NewContrastParam(),
+		NewGammaParam(),
+		NewBlurParam(),
+		NewSharpenParam(),
+	}
+}
+
+// NewNumColorsParam 创建调色板颜色数参数定义
+func NewNumColorsParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamNumColors,
+		Desc:     "编码为 GIF 时调色板的颜色数（含透明色），范围从 2 到 256，越少文件越小。",
+		Default:  "256",
+		Required: false,
+		Schema:   numColorsSchema,
+	}
+}
+
+// NewQuantizerParam 创建颜色量化算法参数定义
+func NewQuantizerParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamQuantizer,
+		Desc: "编码为 GIF 时生成调色板的算法：median-cut-中位切分，按图片颜色分布生成自适应调色板；" +
+			"octree-八叉树，自适应调色板，速度较快；plan9-固定的 Plan9 调色板，忽略 num_colors。",
+		Default:  string(QuantizerMedianCut),
+		Required: false,
+		Schema:   quantizerSchema,
 	}
-	v, err := strconv.ParseInt(value, 10, 64)
-	if err != nil {
-		return exception.Wrapf(err, "param value must be a positive integer")
+}
+
+// NewDitherParam 创建抖动算法参数定义
+func NewDitherParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamDither,
+		Desc:     "编码为 GIF 时的抖动算法：none-不抖动，适合图标、像素画；floyd-steinberg-误差扩散，渐变更平滑，适合照片。",
+		Default:  string(DitherFloydSteinberg),
+		Required: false,
+		Schema:   ditherSchema,
+	}
+}
+
+// NewTransparencyParam 创建保留透明参数定义
+func NewTransparencyParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamTransparency,
+		Desc: "编码为 GIF 时是否保留透明：为 true 时 alpha 低于 128 的像素使用调色板中的透明色（占用一个颜色）；" +
+			"为 false 时铺在 background 上。",
+		Default:  "true",
+		Required: false,
+		Schema:   boolSchema,
 	}
-	if v < 1 || v > 100 {
-		return exception.Errorf("param value must be in range [1, 100]")
+}
+
+// NewGIFParams 创建 GIF 编码相关的参数定义：num_colors、quantizer、dither、transparency
+func NewGIFParams() []contract.ConverterParam {
+	return []contract.ConverterParam{
+		NewNumColorsParam(),
+		NewQuantizerParam(),
+		NewDitherParam(),
+		NewTransparencyParam(),
+	}
+}
+
+// NewFrameParam 创建动画帧序号参数定义
+func NewFrameParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamFrame,
+		Desc:     "源图为动画时输出的帧序号（从 0 开始），frame_mode 为 single 或目标格式不支持动画时生效。",
+		Default:  "0",
+		Required: false,
+		Schema:   positiveIntSchema,
+	}
+}
+
+// NewFrameModeParam 创建动画帧输出模式参数定义
+func NewFrameModeParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamFrameMode,
+		Desc: "源图为动画时的输出模式：auto-目标格式支持动画时保留全部帧（每一帧都会缩放、处理），否则输出 frame 指定的帧；" +
+			"single-输出 frame 指定的帧；sprite-将全部帧拼接为一张精灵图。逐帧导出请使用 frames 目标格式。",
+		Default:  string(FrameModeAuto),
+		Required: false,
+		Schema:   frameModeSchema,
+	}
+}
+
+// NewSpriteColumnsParam 创建精灵图列数参数定义
+func NewSpriteColumnsParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamSpriteColumns,
+		Desc:     "frame_mode 为 sprite 时每行的帧数，按从左到右、从上到下排列，默认值为 0，表示全部帧排成一行。",
+		Default:  "0",
+		Required: false,
+		Schema:   positiveIntSchema,
+	}
+}
+
+// NewFrameParams 创建动画相关的参数定义：frame、frame_mode、sprite_columns
+func NewFrameParams() []contract.ConverterParam {
+	return []contract.ConverterParam{
+		NewFrameParam(),
+		NewFrameModeParam(),
+		NewSpriteColumnsParam(),
+	}
+}
+
+// NewResizeParams 创建缩放相关的参数定义：width、height、resize_mode、gravity、background、no_upscale
+// 重采样滤镜 filter 仅对位图缩放有意义，由 BaseConverter 额外添加
+func NewResizeParams() []contract.ConverterParam {
+	return []contract.ConverterParam{
+		NewWidthParam(),
+		NewHeightParam(),
+		NewResizeModeParam(),
+		NewGravityParam(),
+		NewPadBackgroundParam(),
+		NewNoUpscaleParam(),
 	}
-	return nil
 }
 
 // ParseResizeParams 解析并返回 width, height 参数
@@ -94,3 +467,115 @@ func ParseQualityParam(params map[string]string) int {
 	}
 	return q
 }
+
+// NewICOSizesParam 创建 ICO 图像尺寸列表参数定义
+func NewICOSizesParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamSizes,
+		Desc: "ICO 包含的图像边长列表，逗号分隔，取值 1-256，例如 16,32,48,256。每个尺寸都由缩放后的图片重新采样，" +
+			"等比缩放到正方形画布内（空白透明）。默认为空，表示只包含一个与图片尺寸相同的图像。",
+		Default:  "",
+		Required: false,
+		Check: func(value string) error {
+			_, err := parseICOSizes(value)
+			return err
+		},
+	}
+}
+
+// NewICOSizeParam 创建 ICO 图像选取尺寸参数定义
+func NewICOSizeParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamSize,
+		Desc: "源图为多图像 ICO 时选取的图像边长：优先选取边长等于 size 的图像，没有时选取不小于 size 的最小图像，" +
+			"均小于 size 时选取最大图像。默认值为 0，表示选取最大图像。",
+		Default:  "0",
+		Required: false,
+		Schema:   pixelSchema,
+	}
+}
+
+// NewICOIndexParam 创建 ICO 图像选取序号参数定义
+func NewICOIndexParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamIndex,
+		Desc:     "源图为多图像 ICO 时选取的图像序号（从 0 开始，按 ICO 目录顺序），指定时优先于 size。默认不指定。",
+		Default:  "",
+		Required: false,
+		Schema:   positiveIntSchema,
+	}
+}
+
+// NewICOSelectParams 创建 ICO 输入的图像选取参数定义：size、index
+func NewICOSelectParams() []contract.ConverterParam {
+	return []contract.ConverterParam{
+		NewICOSizeParam(),
+		NewICOIndexParam(),
+	}
+}
+
+// NewAppNameParam 创建 Web 应用名称参数定义
+func NewAppNameParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamAppName,
+		Desc:     "写入 site.webmanifest 的应用名称（name、short_name），默认为空，表示不写入。",
+		Default:  "",
+		Required: false,
+	}
+}
+
+// NewThemeColorParam 创建 Web 应用主题色参数定义
+func NewThemeColorParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamThemeColor,
+		Desc:     "写入 site.webmanifest 与 HTML 片段（meta theme-color）的主题色，支持 #rrggbb、rgb() 与颜色名，默认白色。",
+		Default:  "white",
+		Required: false,
+		Schema:   colorSchema,
+	}
+}
+
+// NewIconPathParam 创建图标 URL 路径前缀参数定义
+func NewIconPathParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name:     ParamIconPath,
+		Desc:     "site.webmanifest 与 HTML 片段中引用图标文件的 URL 路径前缀，例如 /static/icons/，默认为网站根目录 /。",
+		Default:  "/",
+		Required: false,
+	}
+}
+
+// NewMaskablePaddingParam 创建 maskable 图标安全区留白参数定义
+func NewMaskablePaddingParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamMaskablePadding,
+		Desc: "maskable 图标四周各留出的空白占边长的百分比 (0-40)。系统按圆形、圆角矩形等形状裁剪时，只保证以中心为圆心、半径为边长 40% 的圆内可见；" +
+			"默认值为 22，使正方形图标连同四角都位于该圆内，图标本身不是正方形时可适当减小。",
+		Default:  "22",
+		Required: false,
+		Schema:   maskablePaddingSchema,
+	}
+}
+
+// NewIconBackgroundParam 创建图标包背景色参数定义，覆盖 NewPadBackgroundParam
+func NewIconBackgroundParam() contract.ConverterParam {
+	return contract.ConverterParam{
+		Name: ParamBackground,
+		Desc: "apple-touch-icon 与 maskable 图标的背景色（这些图标不应透明），同时写入 site.webmanifest 的 background_color。" +
+			"支持 #rrggbb、rgb() 与颜色名，默认白色。",
+		Default:  "white",
+		Required: false,
+		Schema:   colorSchema,
+	}
+}
+
+// NewIconBundleParams 创建图标包相关的参数定义：app_name、theme_color、icon_path、maskable_padding、background
+func NewIconBundleParams() []contract.ConverterParam {
+	return []contract.ConverterParam{
+		NewAppNameParam(),
+		NewThemeColorParam(),
+		NewIconPathParam(),
+		NewMaskablePaddingParam(),
+		NewIconBackgroundParam(),
+	}
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewPNGToAPNGConverter PNG -> APNG 转换器，输出只有一帧的 APNG，避免经 GIF 多跳转换时的颜色量化
+func NewPNGToAPNGConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.PNG(),
+		contract.APNG(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return png.Decode(r)
+		},
+		encodeStaticAPNG,
+	)
+}
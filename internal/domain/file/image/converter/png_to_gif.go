@@ -1,10 +1,9 @@
 package converter
 
 import (
-	"bytes"
 	"image"
-	"image/gif"
 	"image/png"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
@@ -13,11 +12,10 @@ func NewPNGToGIFConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.PNG(),
 		contract.GIF(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		func(r io.Reader, params map[string]string) (image.Image, error) {
 			return png.Decode(r)
 		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
-			return gif.Encode(w, img, nil)
-		},
+		encodeGIF,
+		NewGIFParams()...,
 	)
 }
@@ -1,23 +1,22 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/png"
+	"io"
 
-	"github.com/biessek/golang-ico"
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
 
+// NewPNGToICOConverter PNG -> ICO 转换器，通过 sizes 参数生成包含多个尺寸的图标
 func NewPNGToICOConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.PNG(),
 		contract.ICO(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		func(r io.Reader, params map[string]string) (image.Image, error) {
 			return png.Decode(r)
 		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
-			return ico.Encode(w, img)
-		},
+		encodeICO,
+		NewICOSizesParam(),
 	)
 }
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewPNGToIconBundleConverter PNG -> 图标包转换器
+func NewPNGToIconBundleConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.PNG(),
+		contract.ICONBUNDLE(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return png.Decode(r)
+		},
+		encodeIconBundle,
+		NewIconBundleParams()...,
+	)
+}
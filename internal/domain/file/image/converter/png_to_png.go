@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewPNGToPNGConverter PNG -> PNG 重新编码转换器，用于缩放、图片处理，重新编码会移除文本块、ICC 等元数据
+func NewPNGToPNGConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.PNG(),
+		contract.PNG(),
+		func(r io.Reader, params map[string]string) (image.Image, error) {
+			return png.Decode(r)
+		},
+		func(w io.Writer, img image.Image, params map[string]string) error {
+			return png.Encode(w, img)
+		},
+	)
+}
@@ -3,13 +3,8 @@ package converter
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
-	"fmt"
-	"image"
 	_ "image/png" // 注册 png 解码器
-	"strconv"
 
-	"github.com/wukong-app/ruyi/internal/core"
 	"github.com/wukong-app/ruyi/pkg/contract"
 	"github.com/wukong-app/ruyi/pkg/exception"
 )
@@ -23,7 +18,7 @@ type pngToSvgConverter struct {
 
 func NewPNGToSVGConverter() contract.Converter {
 	params := contract.ConverterParams{}
-	params.Append(NewWidthParam(), NewHeightParam())
+	params.Append(NewResizeParams()...)
 
 	return &pngToSvgConverter{
 		params: params,
@@ -52,43 +47,19 @@ func (s *pngToSvgConverter) Convert(ctx context.Context, in []byte, params map[s
 	if err != nil {
 		return nil, err
 	}
-
-	var (
-		width  int64
-		height int64
-	)
-
-	width, _ = strconv.ParseInt(params[core.ParamWidth], 10, strconv.IntSize)
-	height, _ = strconv.ParseInt(params[core.ParamHeight], 10, strconv.IntSize)
-
-	// 2. 验证图片有效性并获取尺寸
-	img, _, err := image.DecodeConfig(bytes.NewReader(in))
-	if err != nil {
-		return nil, exception.Wrapf(err, "invalid png image")
+	if err = ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	// 3. 确定最终的 SVG 宽高
-	targetW := img.Width
-	targetH := img.Height
-
-	if width > 0 {
-		targetW = int(width)
-	}
-	if height > 0 {
-		targetH = int(height)
+	// 2. 验证图片有效性并获取尺寸（校验像素数）
+	config, err := decodeRasterConfig(bytes.NewReader(in), contract.LimitsOf(ctx))
+	if err != nil {
+		if exception.Is(err, exception.ErrLimitExceeded) {
+			return nil, err
+		}
+		return nil, exception.Wrapf(exception.Join(exception.ErrDecodeFailed, err), "invalid png image")
 	}
 
-	// 4. Base64 编码
-	encoded := base64.StdEncoding.EncodeToString(in)
-
-	// 5. 生成 SVG
-	// 使用 data URI scheme 嵌入图片
-	// viewBox 保持原图尺寸，width/height 使用目标尺寸，这样浏览器会自动缩放
-	svgContent := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>
-<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">
-<svg version="1.1" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="0 0 %d %d">
-<image width="%d" height="%d" xlink:href="data:image/png;base64,%s" />
-</svg>`, targetW, targetH, img.Width, img.Height, img.Width, img.Height, encoded)
-
-	return []byte(svgContent), nil
+	// 3. 生成 SVG
+	return embedInSVG(in, config, "image/png", ParseResizeOptions(params)), nil
 }
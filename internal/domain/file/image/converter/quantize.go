@@ -0,0 +1,235 @@
+package converter
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// colorBucket 直方图中的一个颜色桶：颜色分量之和与像素数
+type colorBucket struct {
+	r, g, b int
+	n       int
+}
+
+// mean 桶内像素的平均颜色
+func (b colorBucket) mean() [3]uint8 {
+	return [3]uint8{uint8(b.r / b.n), uint8(b.g / b.n), uint8(b.b / b.n)}
+}
+
+// colorHistogram 统计不透明像素（alpha >= alphaThreshold）的颜色直方图
+//
+// 说明:
+//
+//	颜色分量按高 6 位分桶，最多 262144 个桶，避免照片中大量独立颜色占用过多内存；
+//	桶内保存分量之和，平均颜色仍是精确值（只有一种颜色的桶即为该颜色本身）。
+func colorHistogram(img *image.NRGBA) []colorBucket {
+	index := make(map[uint32]int)
+	var buckets []colorBucket
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := img.Pix[img.PixOffset(bounds.Min.X, y):img.PixOffset(bounds.Max.X, y)]
+		for i := 0; i+3 < len(row); i += 4 {
+			if row[i+3] < alphaThreshold {
+				continue
+			}
+			key := uint32(row[i]>>2)<<12 | uint32(row[i+1]>>2)<<6 | uint32(row[i+2]>>2)
+			j, ok := index[key]
+			if !ok {
+				j = len(buckets)
+				index[key] = j
+				buckets = append(buckets, colorBucket{})
+			}
+			buckets[j].r += int(row[i])
+			buckets[j].g += int(row[i+1])
+			buckets[j].b += int(row[i+2])
+			buckets[j].n++
+		}
+	}
+	return buckets
+}
+
+// medianCut 中位切分算法：反复沿跨度最大的颜色分量，在像素数的中位处切分颜色最分散的盒子，直到得到 n 个盒子
+func medianCut(buckets []colorBucket, n int) color.Palette {
+	if len(buckets) == 0 || n <= 0 {
+		return nil
+	}
+
+	boxes := [][]colorBucket{buckets}
+	for len(boxes) < n {
+		// 选取 跨度 x 像素数 最大的盒子
+		best, bestScore, bestChannel := -1, 0, 0
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			channel, span := widestChannel(box)
+			pixels := 0
+			for _, b := range box {
+				pixels += b.n
+			}
+			if score := span * pixels; span > 0 && score > bestScore {
+				best, bestScore, bestChannel = i, score, channel
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		box := boxes[best]
+		sort.Slice(box, func(i, j int) bool {
+			return box[i].mean()[bestChannel] < box[j].mean()[bestChannel]
+		})
+		total := 0
+		for _, b := range box {
+			total += b.n
+		}
+		split, acc := 1, 0
+		for i, b := range box[:len(box)-1] {
+			acc += b.n
+			split = i + 1
+			if acc*2 >= total {
+				break
+			}
+		}
+		boxes[best] = box[:split]
+		boxes = append(boxes, box[split:])
+	}
+
+	palette := make(color.Palette, 0, len(boxes))
+	for _, box := range boxes {
+		var sum colorBucket
+		for _, b := range box {
+			sum.r, sum.g, sum.b, sum.n = sum.r+b.r, sum.g+b.g, sum.b+b.b, sum.n+b.n
+		}
+		c := sum.mean()
+		palette = append(palette, color.NRGBA{R: c[0], G: c[1], B: c[2], A: 0xFF})
+	}
+	return palette
+}
+
+// widestChannel 返回盒子中跨度最大的颜色分量及其跨度
+func widestChannel(box []colorBucket) (channel int, span int) {
+	lo := [3]int{255, 255, 255}
+	hi := [3]int{0, 0, 0}
+	for _, b := range box {
+		c := b.mean()
+		for i := 0; i < 3; i++ {
+			lo[i] = min(lo[i], int(c[i]))
+			hi[i] = max(hi[i], int(c[i]))
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if hi[i]-lo[i] > span {
+			channel, span = i, hi[i]-lo[i]
+		}
+	}
+	return channel, span
+}
+
+// octreeDepth 八叉树深度，每层对应颜色分量的一位
+const octreeDepth = 8
+
+// octreeNode 八叉树节点，叶子节点保存颜色分量之和与像素数
+type octreeNode struct {
+	children [8]*octreeNode
+	leaf     bool
+	sum      colorBucket
+}
+
+// octree 八叉树颜色量化器
+type octree struct {
+	root      *octreeNode
+	leaves    int
+	reducible [octreeDepth][]*octreeNode // 各层的非叶子节点，合并时优先合并最深层
+}
+
+// octreeQuantize 八叉树算法：按颜色分量的二进制位逐层插入，叶子数超过 n 时合并最深层的节点
+func octreeQuantize(buckets []colorBucket, n int) color.Palette {
+	if len(buckets) == 0 || n <= 0 {
+		return nil
+	}
+
+	tree := &octree{root: &octreeNode{}}
+	tree.reducible[0] = []*octreeNode{tree.root}
+	for _, b := range buckets {
+		tree.add(b)
+		for tree.leaves > n {
+			if !tree.reduce() {
+				break
+			}
+		}
+	}
+
+	var palette color.Palette
+	var walk func(node *octreeNode)
+	walk = func(node *octreeNode) {
+		if node.leaf {
+			c := node.sum.mean()
+			palette = append(palette, color.NRGBA{R: c[0], G: c[1], B: c[2], A: 0xFF})
+			return
+		}
+		for _, child := range node.children {
+			if child != nil {
+				walk(child)
+			}
+		}
+	}
+	walk(tree.root)
+	return palette
+}
+
+// add 插入一个颜色桶
+func (t *octree) add(b colorBucket) {
+	c := b.mean()
+	node := t.root
+	for level := 0; level < octreeDepth && !node.leaf; level++ {
+		shift := 7 - level
+		i := (c[0]>>shift&1)<<2 | (c[1]>>shift&1)<<1 | c[2]>>shift&1
+		child := node.children[i]
+		if child == nil {
+			child = &octreeNode{}
+			node.children[i] = child
+			if level == octreeDepth-1 {
+				child.leaf = true
+				t.leaves++
+			} else {
+				t.reducible[level+1] = append(t.reducible[level+1], child)
+			}
+		}
+		node = child
+	}
+	node.sum.r += b.r
+	node.sum.g += b.g
+	node.sum.b += b.b
+	node.sum.n += b.n
+}
+
+// reduce 将最深层的一个非叶子节点与其子节点合并为叶子，没有可合并的节点时返回 false
+func (t *octree) reduce() bool {
+	for level := octreeDepth - 1; level >= 0; level-- {
+		nodes := t.reducible[level]
+		if len(nodes) == 0 {
+			continue
+		}
+		node := nodes[len(nodes)-1]
+		t.reducible[level] = nodes[:len(nodes)-1]
+
+		children := 0
+		for i, child := range node.children {
+			if child == nil {
+				continue
+			}
+			node.sum.r += child.sum.r
+			node.sum.g += child.sum.g
+			node.sum.b += child.sum.b
+			node.sum.n += child.sum.n
+			node.children[i] = nil
+			children++
+		}
+		node.leaf = true
+		t.leaves -= children - 1
+		return true
+	}
+	return false
+}
@@ -0,0 +1,299 @@
+package converter
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// ResizeMode 缩放模式
+type ResizeMode string
+
+const (
+	ResizeExact ResizeMode = "exact" // 拉伸到 width x height，不保持比例（默认）
+	ResizeFit   ResizeMode = "fit"   // 保持比例缩放到框内，输出可能小于 width x height
+	ResizeCover ResizeMode = "cover" // 保持比例缩放到覆盖整个框，输出可能大于 width x height，不裁剪
+	ResizeFill  ResizeMode = "fill"  // 保持比例缩放到覆盖整个框，再按 gravity 裁剪为 width x height
+	ResizePad   ResizeMode = "pad"   // 保持比例缩放到框内，再按 gravity 放置在 width x height 的 background 画布上
+)
+
+// Gravity 裁剪、填充时的锚点
+type Gravity string
+
+const (
+	GravityCenter      Gravity = "center"
+	GravityTop         Gravity = "top"
+	GravityBottom      Gravity = "bottom"
+	GravityLeft        Gravity = "left"
+	GravityRight       Gravity = "right"
+	GravityTopLeft     Gravity = "top_left"
+	GravityTopRight    Gravity = "top_right"
+	GravityBottomLeft  Gravity = "bottom_left"
+	GravityBottomRight Gravity = "bottom_right"
+)
+
+// gravityAlign 锚点在水平、垂直方向上的相对位置：0-起始，0.5-居中，1-末尾
+var gravityAlign = map[Gravity][2]float64{
+	GravityCenter:      {0.5, 0.5},
+	GravityTop:         {0.5, 0},
+	GravityBottom:      {0.5, 1},
+	GravityLeft:        {0, 0.5},
+	GravityRight:       {1, 0.5},
+	GravityTopLeft:     {0, 0},
+	GravityTopRight:    {1, 0},
+	GravityBottomLeft:  {0, 1},
+	GravityBottomRight: {1, 1},
+}
+
+// Filter 重采样滤镜
+type Filter string
+
+const (
+	FilterNearest    Filter = "nearest"     // 最近邻，适用于像素画与图标
+	FilterBox        Filter = "box"         // 盒式滤波，缩小时速度快
+	FilterLinear     Filter = "linear"      // 双线性
+	FilterHermite    Filter = "hermite"     // Hermite
+	FilterMitchell   Filter = "mitchell"    // Mitchell-Netravali
+	FilterCatmullRom Filter = "catmull-rom" // Catmull-Rom，锐利的双三次
+	FilterBSpline    Filter = "bspline"     // B 样条，平滑
+	FilterGaussian   Filter = "gaussian"    // 高斯，模糊
+	FilterLanczos    Filter = "lanczos"     // Lanczos，画质最好但最慢（默认）
+)
+
+// _filters 重采样滤镜，按速度从快到慢排列
+var _filters = []struct {
+	name   Filter
+	filter imaging.ResampleFilter
+}{
+	{FilterNearest, imaging.NearestNeighbor},
+	{FilterBox, imaging.Box},
+	{FilterLinear, imaging.Linear},
+	{FilterHermite, imaging.Hermite},
+	{FilterMitchell, imaging.MitchellNetravali},
+	{FilterCatmullRom, imaging.CatmullRom},
+	{FilterBSpline, imaging.BSpline},
+	{FilterGaussian, imaging.Gaussian},
+	{FilterLanczos, imaging.Lanczos},
+}
+
+// filterNames 全部重采样滤镜名称
+func filterNames() []string {
+	names := make([]string, 0, len(_filters))
+	for _, f := range _filters {
+		names = append(names, string(f.name))
+	}
+	return names
+}
+
+// resampleFilter 根据名称获取重采样滤镜，未知名称返回 imaging.Lanczos
+func resampleFilter(name Filter) imaging.ResampleFilter {
+	for _, f := range _filters {
+		if f.name == name {
+			return f.filter
+		}
+	}
+	return imaging.Lanczos
+}
+
+// ResizeOptions 缩放配置，由 ParseResizeOptions 从转换器参数解析
+type ResizeOptions struct {
+	Width      int         // 目标宽度，0 表示按比例计算
+	Height     int         // 目标高度，0 表示按比例计算
+	Mode       ResizeMode  // 缩放模式
+	Gravity    Gravity     // 裁剪、填充时的锚点
+	Background color.NRGBA // pad 模式的背景色
+	Filter     Filter      // 重采样滤镜
+	NoUpscale  bool        // 是否禁止放大
+}
+
+// ParseResizeOptions 解析缩放相关参数（参数应已通过校验）
+func ParseResizeOptions(params map[string]string) ResizeOptions {
+	width, height := ParseResizeParams(params)
+	opts := ResizeOptions{
+		Width:   int(width),
+		Height:  int(height),
+		Mode:    ResizeMode(strings.ToLower(params[ParamResizeMode])),
+		Gravity: Gravity(strings.ToLower(params[ParamGravity])),
+	}
+	if opts.Mode == "" {
+		opts.Mode = ResizeExact
+	}
+	if _, ok := gravityAlign[opts.Gravity]; !ok {
+		opts.Gravity = GravityCenter
+	}
+	if params[ParamBackground] != "" {
+		opts.Background, _ = contract.ParseColor(params[ParamBackground])
+	}
+	opts.Filter = Filter(strings.ToLower(params[ParamFilter]))
+	if opts.Filter == "" {
+		opts.Filter = FilterLanczos
+	}
+	opts.NoUpscale, _ = strconv.ParseBool(params[ParamNoUpscale])
+	return opts
+}
+
+// boxed 是否同时指定了宽高（此时缩放模式才有区别）
+func (o ResizeOptions) boxed() bool {
+	return o.Width > 0 && o.Height > 0
+}
+
+// ScaledSize 计算缩放后（裁剪、填充前）的尺寸
+//
+// 说明:
+//
+//	只指定宽高之一时，所有模式均按原比例计算另一边；均未指定时保持原尺寸。
+//	NoUpscale 为 true 时，缩放比例不超过 1；exact 模式将目标宽高按同一比例缩小到不超过源图，保持目标框的比例。
+func (o ResizeOptions) ScaledSize(srcW, srcH int) (int, int) {
+	if srcW <= 0 || srcH <= 0 {
+		return resizedSize(srcW, srcH, int64(o.Width), int64(o.Height))
+	}
+	if !o.boxed() {
+		w, h := resizedSize(srcW, srcH, int64(o.Width), int64(o.Height))
+		if o.NoUpscale && (w > srcW || h > srcH) {
+			return srcW, srcH
+		}
+		return w, h
+	}
+
+	scaleW := float64(o.Width) / float64(srcW)
+	scaleH := float64(o.Height) / float64(srcH)
+	var scale float64
+	switch o.Mode {
+	case ResizeFit, ResizePad:
+		scale = math.Min(scaleW, scaleH)
+	case ResizeCover, ResizeFill:
+		scale = math.Max(scaleW, scaleH)
+	default:
+		if o.NoUpscale {
+			return noUpscaleBox(o.Width, o.Height, srcW, srcH)
+		}
+		return o.Width, o.Height
+	}
+	if o.NoUpscale {
+		scale = math.Min(scale, 1)
+	}
+	return max(1, int(math.Round(float64(srcW)*scale))), max(1, int(math.Round(float64(srcH)*scale)))
+}
+
+// noUpscaleBox 将 width x height 的目标框按同一比例缩小到宽、高均不超过源图，目标框本身不超过源图时保持不变
+func noUpscaleBox(width, height, srcW, srcH int) (int, int) {
+	scale := math.Min(1, math.Min(float64(srcW)/float64(width), float64(srcH)/float64(height)))
+	if scale == 1 {
+		return width, height
+	}
+	return max(1, int(math.Round(float64(width)*scale))), max(1, int(math.Round(float64(height)*scale)))
+}
+
+// Size 计算最终输出尺寸
+//
+// 说明:
+//
+//	fill 模式裁剪为 width x height（禁止放大且源图较小时不超过缩放后的尺寸）；pad 模式总是输出完整画布。
+func (o ResizeOptions) Size(srcW, srcH int) (int, int) {
+	if o.boxed() {
+		switch o.Mode {
+		case ResizeFill:
+			scaledW, scaledH := o.ScaledSize(srcW, srcH)
+			return min(o.Width, scaledW), min(o.Height, scaledH)
+		case ResizePad:
+			return o.Width, o.Height
+		}
+	}
+	return o.ScaledSize(srcW, srcH)
+}
+
+// Apply 按配置缩放图片，返回 NRGBA 图片
+//
+// 说明:
+//
+//	尺寸不变时不重新采样，仅标准化像素格式（例如 HEIC 的 YCbCr），保证后续编码的兼容性。
+//	缩放分带执行（见 resizeImage），ctx 结束时返回 ctx 的错误。
+func (o ResizeOptions) Apply(ctx context.Context, img image.Image) (*image.NRGBA, error) {
+	bounds := img.Bounds()
+	scaledW, scaledH := o.ScaledSize(bounds.Dx(), bounds.Dy())
+	out, err := resizeImage(ctx, img, scaledW, scaledH, resampleFilter(o.Filter))
+	if err != nil {
+		return nil, err
+	}
+
+	if !o.boxed() {
+		return out, nil
+	}
+	switch o.Mode {
+	case ResizeFill:
+		x, y := o.offset(scaledW, scaledH)
+		x, y = min(x, 0), min(y, 0) // 禁止放大时缩放后的图片可能小于目标框
+		return imaging.Crop(out, image.Rect(-x, -y, -x+o.Width, -y+o.Height)), nil
+	case ResizePad:
+		canvas := imaging.New(o.Width, o.Height, o.Background)
+		x, y := o.offset(scaledW, scaledH)
+		return imaging.Overlay(canvas, out, image.Pt(x, y), 1), nil
+	default:
+		return out, nil
+	}
+}
+
+// resizeImage 缩放为 width x height，结果与 imaging.Resize 相同，尺寸不变时复制为 NRGBA
+//
+// 说明:
+//
+//	imaging.Resize 先水平、后垂直两遍重新采样：水平一遍每行互不相关，按行分带；垂直一遍每列互不相关，按列分带。
+//	每带之间检查 ctx，取消后不再继续计算。
+func resizeImage(ctx context.Context, img image.Image, width, height int, filter imaging.ResampleFilter) (*image.NRGBA, error) {
+	srcW, srcH := img.Bounds().Dx(), img.Bounds().Dy()
+	out, err := inRowBands(ctx, img, width, 0, func(band image.Image) *image.NRGBA {
+		if width == srcW {
+			return imaging.Clone(band)
+		}
+		return imaging.Resize(band, width, band.Bounds().Dy(), filter)
+	})
+	if err != nil || height == srcH {
+		return out, err
+	}
+	return inColumnBands(ctx, out, height, func(band image.Image) *image.NRGBA {
+		return imaging.Resize(band, band.Bounds().Dx(), height, filter)
+	})
+}
+
+// offset 按 gravity 计算 w x h 的图片放置在目标框中的左上角坐标（图片大于目标框时为负数）
+func (o ResizeOptions) offset(w, h int) (int, int) {
+	align := gravityAlign[o.Gravity]
+	x := int(math.Round(float64(o.Width-w) * align[0]))
+	y := int(math.Round(float64(o.Height-h) * align[1]))
+	return x, y
+}
+
+// PreserveAspectRatio 对应 SVG preserveAspectRatio 属性值，用于将位图嵌入 SVG
+//
+// 说明:
+//
+//	exact 模式返回空字符串，表示沿用 SVG 默认行为。
+func (o ResizeOptions) PreserveAspectRatio() string {
+	align := gravityAlign[o.Gravity]
+	pos := func(v float64) string {
+		switch v {
+		case 0:
+			return "Min"
+		case 1:
+			return "Max"
+		default:
+			return "Mid"
+		}
+	}
+	value := "x" + pos(align[0]) + "Y" + pos(align[1])
+
+	switch o.Mode {
+	case ResizeFill:
+		return value + " slice"
+	case ResizeFit, ResizeCover, ResizePad:
+		return value + " meet"
+	default:
+		return ""
+	}
+}
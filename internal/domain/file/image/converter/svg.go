@@ -0,0 +1,121 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// decodeSVGConfig 读取 SVG 的 viewBox 尺寸，解析前校验元素个数
+//
+// 说明:
+//
+//	SVG 是矢量图，源尺寸不受像素数限制，光栅化尺寸由 BaseConverter 按输出尺寸限制校验。
+//	解析结果会被缓存（见 cacheDecoded），decodeSVG 直接复用，不再重复解析。
+func decodeSVGConfig(r io.Reader, limits contract.Limits) (image.Config, error) {
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	if err = checkSVGElements(in, limits); err != nil {
+		return image.Config{}, err
+	}
+
+	icon, err := oksvg.ReadIconStream(bytes.NewReader(in))
+	if err != nil {
+		return image.Config{}, err
+	}
+	cacheDecoded(r, icon)
+	return image.Config{Width: int(icon.ViewBox.W), Height: int(icon.ViewBox.H)}, nil
+}
+
+// decodeSVG 解析 SVG 并直接光栅化为缩放后的尺寸（见 ResizeOptions.ScaledSize），避免先光栅化再缩放造成的模糊
+//
+// 说明:
+//
+//	读取图片头时已解析的 SVG 直接复用（见 decodeSVGConfig）；逐个路径光栅化，每个路径之前检查 r 所属转换的 ctx（见 readerContext）。
+func decodeSVG(r io.Reader, params map[string]string) (image.Image, error) {
+	ctx := readerContext(r)
+	icon, ok := cachedDecoded(r).(*oksvg.SvgIcon)
+	if !ok {
+		var err error
+		if icon, err = oksvg.ReadIconStream(r); err != nil {
+			return nil, err
+		}
+	}
+
+	// 存在裁剪、旋转时按 viewBox 尺寸光栅化，由 BaseConverter 在几何变换之后再缩放
+	targetW, targetH := int(icon.ViewBox.W), int(icon.ViewBox.H)
+	if !ParseOperations(params).Geometric() {
+		targetW, targetH = ParseResizeOptions(params).ScaledSize(targetW, targetH)
+	}
+	if targetW <= 0 || targetH <= 0 {
+		return nil, exception.Errorf("invalid svg size %dx%d", targetW, targetH)
+	}
+	icon.SetTarget(0, 0, float64(targetW), float64(targetH))
+
+	rgba := image.NewRGBA(image.Rect(0, 0, targetW, targetH))
+	dasher := rasterx.NewDasher(targetW, targetH, rasterx.NewScannerGV(targetW, targetH, rgba, rgba.Bounds()))
+	for _, path := range icon.SVGPaths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		path.DrawTransformed(dasher, 1, icon.Transform)
+	}
+	return rgba, nil
+}
+
+// embedInSVG 将位图以 data URI 嵌入 SVG
+//
+// 说明:
+//
+//	viewBox 保持原图尺寸，width/height 使用目标尺寸，由浏览器按 preserveAspectRatio 缩放。
+//	exact 模式沿用原有行为：未指定的一边保持原图尺寸；其余模式按 ResizeOptions.Size 计算输出尺寸。
+func embedInSVG(in []byte, config image.Config, mimeType string, opts ResizeOptions) []byte {
+	targetW, targetH := config.Width, config.Height
+	if opts.Mode == ResizeExact {
+		if opts.Width > 0 {
+			targetW = opts.Width
+		}
+		if opts.Height > 0 {
+			targetH = opts.Height
+		}
+		if opts.NoUpscale {
+			targetW, targetH = noUpscaleBox(targetW, targetH, config.Width, config.Height)
+		}
+	} else {
+		targetW, targetH = opts.Size(config.Width, config.Height)
+	}
+
+	var (
+		aspect     string
+		background string
+	)
+	if value := opts.PreserveAspectRatio(); value != "" {
+		aspect = fmt.Sprintf(` preserveAspectRatio="%s"`, value)
+	}
+	if opts.Mode == ResizePad && opts.Background.A > 0 {
+		bg := opts.Background
+		background = fmt.Sprintf(
+			"\n<rect width=\"100%%\" height=\"100%%\" fill=\"#%02x%02x%02x\" fill-opacity=\"%.3g\" />",
+			bg.R, bg.G, bg.B, float64(bg.A)/0xFF,
+		)
+	}
+
+	// 使用 data URI scheme 嵌入图片
+	encoded := base64.StdEncoding.EncodeToString(in)
+	svgContent := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">
+<svg version="1.1" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="0 0 %d %d"%s>%s
+<image width="%d" height="%d" xlink:href="data:%s;base64,%s" />
+</svg>`, targetW, targetH, config.Width, config.Height, aspect, background, config.Width, config.Height, mimeType, encoded)
+
+	return []byte(svgContent)
+}
@@ -0,0 +1,16 @@
+package converter
+
+import (
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewSVGToICOConverter SVG -> ICO 转换器，通过 sizes 参数生成包含多个尺寸的图标，未指定宽高时按最大尺寸光栅化
+func NewSVGToICOConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.SVG(),
+		contract.ICO(),
+		decodeSVGForICO,
+		encodeICO,
+		NewICOSizesParam(),
+	).WithDecodeConfig(decodeSVGConfig)
+}
@@ -0,0 +1,16 @@
+package converter
+
+import (
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// NewSVGToIconBundleConverter SVG -> 图标包转换器，未指定宽高时按最大图标尺寸光栅化
+func NewSVGToIconBundleConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.SVG(),
+		contract.ICONBUNDLE(),
+		decodeSVGForIconBundle,
+		encodeIconBundle,
+		NewIconBundleParams()...,
+	).WithDecodeConfig(decodeSVGConfig)
+}
@@ -1,9 +1,9 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/png"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 	"golang.org/x/image/tiff"
@@ -13,11 +13,12 @@ func NewTIFFToPNGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.TIFF(),
 		contract.PNG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		autoOrient(func(r io.Reader, params map[string]string) (image.Image, error) {
 			return tiff.Decode(r)
-		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
+		}, tiffOrientation),
+		func(w io.Writer, img image.Image, params map[string]string) error {
 			return png.Encode(w, img)
 		},
+		NewAutoOrientParam(),
 	)
 }
@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"image"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"golang.org/x/image/tiff"
+)
+
+// NewTIFFToTIFFConverter TIFF -> TIFF 重新编码转换器，用于缩放、图片处理，重新编码会移除 EXIF 等元数据
+func NewTIFFToTIFFConverter() contract.Converter {
+	return NewBaseConverter(
+		contract.TIFF(),
+		contract.TIFF(),
+		autoOrient(func(r io.Reader, params map[string]string) (image.Image, error) {
+			return tiff.Decode(r)
+		}, tiffOrientation),
+		func(w io.Writer, img image.Image, params map[string]string) error {
+			return tiff.Encode(w, img, nil)
+		},
+		NewAutoOrientParam(),
+	)
+}
@@ -1,9 +1,8 @@
 package converter
 
 import (
-	"bytes"
 	"image"
-	"image/jpeg"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 	"golang.org/x/image/webp"
@@ -13,12 +12,11 @@ func NewWEBPToJPEGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.WEBP(),
 		contract.JPEG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		func(r io.Reader, params map[string]string) (image.Image, error) {
 			return webp.Decode(r)
 		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
-			return jpeg.Encode(w, img, &jpeg.Options{Quality: ParseQualityParam(params)})
-		},
+		encodeJPEG,
 		NewQualityParam(),
+		NewFlattenBackgroundParam(),
 	)
 }
@@ -1,9 +1,9 @@
 package converter
 
 import (
-	"bytes"
 	"image"
 	"image/png"
+	"io"
 
 	"github.com/wukong-app/ruyi/pkg/contract"
 	"golang.org/x/image/webp"
@@ -13,10 +13,10 @@ func NewWEBPToPNGConverter() contract.Converter {
 	return NewBaseConverter(
 		contract.WEBP(),
 		contract.PNG(),
-		func(r *bytes.Reader, params map[string]string) (image.Image, error) {
+		func(r io.Reader, params map[string]string) (image.Image, error) {
 			return webp.Decode(r)
 		},
-		func(w *bytes.Buffer, img image.Image, params map[string]string) error {
+		func(w io.Writer, img image.Image, params map[string]string) error {
 			return png.Encode(w, img)
 		},
 	)
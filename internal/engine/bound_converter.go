@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"context"
+	"io"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+var (
+	_ contract.StreamConverter = (*boundConverter)(nil)
+	_ contract.ChainConverter  = (*boundChainConverter)(nil)
+)
+
+// boundConverter 绑定了 Ruyi 实例配置的转换器
+//
+// 说明:
+//
+//	通过 GetConverter、ListConverters 取得的转换器同样遵循实例的严格参数模式与资源限制（见 ruyi.WithStrictParams、ruyi.WithLimits），
+//	与 Ruyi.Convert 的行为一致；context 中的配置优先于实例配置。
+type boundConverter struct {
+	converter contract.Converter
+	ruyi      *Ruyi
+}
+
+// boundChainConverter 绑定了 Ruyi 实例配置的多跳复合转换器，保留 contract.ChainConverter 接口
+type boundChainConverter struct {
+	*boundConverter
+	chain *chainConverter
+}
+
+// bindConverter 为转换器绑定实例配置，converter 为 nil 时返回 nil
+func (s *Ruyi) bindConverter(converter contract.Converter) contract.Converter {
+	if converter == nil {
+		return nil
+	}
+	bound := &boundConverter{converter: converter, ruyi: s}
+	if chain, ok := converter.(*chainConverter); ok {
+		return &boundChainConverter{boundConverter: bound, chain: chain}
+	}
+	return bound
+}
+
+func (s *boundConverter) From() contract.Concept {
+	return s.converter.From()
+}
+
+func (s *boundConverter) To() contract.Concept {
+	return s.converter.To()
+}
+
+func (s *boundConverter) Params() []contract.ConverterParam {
+	return s.converter.Params()
+}
+
+// Convert 严格模式下预先校验参数，注入资源限制并校验输入字节数后执行转换
+func (s *boundConverter) Convert(ctx context.Context, in []byte, params map[string]string) ([]byte, error) {
+	if err := s.ruyi.checkParams(ctx, s.converter, params); err != nil {
+		return nil, err
+	}
+
+	ctx = s.ruyi.withLimits(ctx)
+	if err := contract.LimitsOf(ctx).CheckInputBytes(int64(len(in))); err != nil {
+		return nil, err
+	}
+	return s.converter.Convert(ctx, in, params)
+}
+
+// ConvertStream 严格模式下预先校验参数，注入资源限制并限制读取的字节数后流式执行转换
+func (s *boundConverter) ConvertStream(ctx context.Context, r io.Reader, w io.Writer, params map[string]string) error {
+	if err := s.ruyi.checkParams(ctx, s.converter, params); err != nil {
+		return err
+	}
+
+	ctx = s.ruyi.withLimits(ctx)
+	limitedReader := newLimitReader(r, contract.LimitsOf(ctx))
+	err := convertStream(ctx, s.converter, limitedReader, w, params)
+	if limitErr := limitedReader.Err(); limitErr != nil {
+		// 超限错误优先：部分解码器会丢失错误链，或在读取到超限数据后仍然解码成功
+		return limitErr
+	}
+	return err
+}
+
+func (s *boundChainConverter) Path() []contract.Converter {
+	return s.chain.Path()
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+var (
+	_ contract.ChainConverter  = (*chainConverter)(nil)
+	_ contract.StreamConverter = (*chainConverter)(nil)
+)
+
+// paramHopSeparator 参数前缀分隔符，例如 png.width 表示只传给目标为 png 的那一跳
+const paramHopSeparator = "."
+
+// chainConverter 多跳复合转换器，依次执行路径上的各个转换器
+type chainConverter struct {
+	path []contract.Converter
+}
+
+// newChainConverter 创建多跳复合转换器
+// @param path 转换路径（按执行顺序），长度至少为 2
+func newChainConverter(path []contract.Converter) *chainConverter {
+	copyPath := make([]contract.Converter, len(path))
+	copy(copyPath, path)
+	return &chainConverter{
+		path: copyPath,
+	}
+}
+
+func (s *chainConverter) From() contract.Concept {
+	return s.path[0].From()
+}
+
+func (s *chainConverter) To() contract.Concept {
+	return s.path[len(s.path)-1].To()
+}
+
+func (s *chainConverter) Path() []contract.Converter {
+	copyPath := make([]contract.Converter, len(s.path))
+	copy(copyPath, s.path)
+	return copyPath
+}
+
+// Params 返回路径上所有转换器参数的并集，同名参数以最后一个声明者为准
+func (s *chainConverter) Params() []contract.ConverterParam {
+	var (
+		index  = make(map[string]int)
+		params []contract.ConverterParam
+	)
+	for _, converter := range s.path {
+		for _, param := range converter.Params() {
+			if i, exist := index[param.Name]; exist {
+				params[i] = param
+				continue
+			}
+			index[param.Name] = len(params)
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// Convert 依次执行路径上的转换器，上一跳的输出作为下一跳的输入
+func (s *chainConverter) Convert(ctx context.Context, in []byte, params map[string]string) (out []byte, err error) {
+	var buf bytes.Buffer
+	if err = s.ConvertStream(ctx, bytes.NewReader(in), &buf, params); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ConvertStream 流式执行多跳转换：第一跳从 r 读取，最后一跳写入 w，中间结果暂存于内存
+func (s *chainConverter) ConvertStream(ctx context.Context, r io.Reader, w io.Writer, params map[string]string) error {
+	hopParams := s.routeParams(params)
+
+	in := r
+	for i, converter := range s.path {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var (
+			buf bytes.Buffer
+			out = io.Writer(&buf)
+		)
+		if i == len(s.path)-1 {
+			out = w
+		}
+
+		if err := convertStream(ctx, converter, in, out, hopParams[i]); err != nil {
+			return exception.Wrapf(
+				err,
+				"hop %d/%d failed: %s -> %s",
+				i+1, len(s.path), converter.From().Name(), converter.To().Name(),
+			)
+		}
+		in = &buf
+	}
+	return nil
+}
+
+// routeParams 将调用方参数路由到各跳
+//
+// 说明:
+//
+//	1、形如 <concept>.<name> 的参数路由到目标 Concept 为 <concept> 的那一跳（支持别名）；
+//	2、其余参数路由到路径上最后一个声明了该参数的转换器；无人声明时交给最后一跳处理。
+func (s *chainConverter) routeParams(params map[string]string) []map[string]string {
+	hopParams := make([]map[string]string, len(s.path))
+	for i := range hopParams {
+		hopParams[i] = make(map[string]string)
+	}
+
+	for key, value := range params {
+		if hop, name, ok := s.prefixedHop(key); ok {
+			hopParams[hop][name] = value
+			continue
+		}
+		hopParams[s.declaringHop(key)][key] = value
+	}
+	return hopParams
+}
+
+// prefixedHop 解析带 Concept 前缀的参数，返回目标跳下标与去掉前缀后的参数名
+func (s *chainConverter) prefixedHop(key string) (hop int, name string, ok bool) {
+	prefix, name, found := strings.Cut(key, paramHopSeparator)
+	if !found || name == "" {
+		return 0, "", false
+	}
+	concept, exist := contract.NormalizeConcept(contract.ConceptName(prefix))
+	if !exist {
+		return 0, "", false
+	}
+	for i, converter := range s.path {
+		if converter.To().Name() == concept.Name() {
+			return i, name, true
+		}
+	}
+	return 0, "", false
+}
+
+// declaringHop 返回最后一个声明了参数 name 的跳下标，无人声明时返回最后一跳
+func (s *chainConverter) declaringHop(name string) int {
+	for i := len(s.path) - 1; i >= 0; i-- {
+		for _, param := range s.path[i].Params() {
+			if param.Name == name {
+				return i
+			}
+		}
+	}
+	return len(s.path) - 1
+}
+
+// checkParams 严格校验参数：按 routeParams 的规则路由后逐跳校验，未被任何一跳声明的参数视为未知参数
+func (s *chainConverter) checkParams(params map[string]string) error {
+	var errs []error
+	for i, hopParams := range s.routeParams(params) {
+		errs = append(errs, contract.NewConverterParams(s.path[i].Params()...).CheckParams(hopParams, true))
+	}
+	return exception.Join(errs...)
+}
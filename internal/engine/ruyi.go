@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"io"
 	"math"
 	"sync"
 	"sync/atomic"
@@ -17,6 +18,7 @@ var _ contract.Ruyi = (*Ruyi)(nil)
 //
 // 参数:
 //   - converterRegistry: Converter 注册中心，用于管理各种转换器
+//   - options: 实例配置，为 nil 时使用默认配置
 //
 // 返回值:
 //   - contract.Ruyi 接口类型的实例
@@ -26,15 +28,24 @@ var _ contract.Ruyi = (*Ruyi)(nil)
 //	NewRuyi 用于对外创建 Ruyi 实例，隐藏内部实现细节。
 //	调用方无需关心内部结构，只通过接口使用核心功能。
 //	如果 converterRegistry 为 nil，则会 panic。
-func NewRuyi(converterRegistry core.ConverterRegistry) contract.Ruyi {
+func NewRuyi(converterRegistry core.ConverterRegistry, options *core.Options) contract.Ruyi {
 	if converterRegistry == nil {
 		panic("converterRegistry cannot be nil")
 	}
+	if options == nil {
+		options = &core.Options{}
+	}
+	limits := contract.DefaultLimits()
+	if options.Limits != nil {
+		limits = *options.Limits
+	}
 
 	return &Ruyi{
 		description:       "The Ruyi Jingu Bang, Sun Wukong’s magic staff, weighs thirteen thousand five hundred jin.",
 		size:              20,
 		converterRegister: converterRegistry,
+		strictParams:      options.StrictParams,
+		limits:            limits,
 	}
 }
 
@@ -56,19 +67,85 @@ type Ruyi struct {
 	//	依赖组件
 	//////////////////////////////
 	converterRegister core.ConverterRegistry // Converter 注册中心
+
+	//////////////////////////////
+	//	配置
+	//////////////////////////////
+	strictParams bool            // 是否启用严格参数模式
+	limits       contract.Limits // 资源限制
 }
 
+// GetConverter 获取转换器，返回的转换器绑定了实例的严格参数模式与资源限制（见 boundConverter）
 func (s *Ruyi) GetConverter(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) (contract.Converter, error) {
 	// 查找对应 Converter
-	converter := s.converterRegister.Find(ctx, kind, from, to)
+	converter := s.findConverter(ctx, kind, from, to)
 	if converter == nil {
-		return nil, exception.Wrapf(
-			exception.ErrNoSupportedConverter,
-			"converter not found for kind=%s: %s -> %s",
-			kind, from, to,
-		)
+		return nil, exception.NewRuyiError(exception.CodeNoConverter, nil).
+			WithMessage("converter not found").
+			WithConversion(string(kind), string(from), string(to))
+	}
+	return s.bindConverter(converter), nil
+}
+
+// findConverter 查找转换器，优先使用直接转换器，不存在时规划多跳路径并串联为 ChainConverter
+func (s *Ruyi) findConverter(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) contract.Converter {
+	if converter := s.converterRegister.Find(ctx, kind, from, to); converter != nil {
+		return converter
+	}
+
+	path := s.converterRegister.FindPath(ctx, kind, from, to)
+	switch len(path) {
+	case 0:
+		return nil
+	case 1:
+		return path[0]
+	default:
+		return newChainConverter(path)
 	}
-	return converter, nil
+}
+
+// ListKinds 获取所有已注册转换器的种类
+func (s *Ruyi) ListKinds() []contract.Kind {
+	return s.converterRegister.Kinds()
+}
+
+// ListConcepts 获取指定种类下的所有已知概念
+func (s *Ruyi) ListConcepts(kind contract.Kind) []contract.Concept {
+	return contract.ConceptsByKind(kind)
+}
+
+// ListConverters 获取指定种类下的所有直接转换器，返回的转换器绑定了实例配置（见 boundConverter）
+func (s *Ruyi) ListConverters(kind contract.Kind) []contract.Converter {
+	converters := s.converterRegister.List(kind)
+	for i, converter := range converters {
+		converters[i] = s.bindConverter(converter)
+	}
+	return converters
+}
+
+// TargetsFrom 获取源概念可以转换到的所有目标概念（含多跳路径）
+func (s *Ruyi) TargetsFrom(kind contract.Kind, from contract.ConceptName) []contract.Concept {
+	return s.converterRegister.Targets(kind, from)
+}
+
+// SourcesTo 获取可以转换到目标概念的所有源概念（含多跳路径）
+func (s *Ruyi) SourcesTo(kind contract.Kind, to contract.ConceptName) []contract.Concept {
+	return s.converterRegister.Sources(kind, to)
+}
+
+// RegisterConverters 注册转换器
+func (s *Ruyi) RegisterConverters(converters ...contract.Converter) error {
+	return s.converterRegister.Register(converters...)
+}
+
+// ReplaceConverters 注册转换器（替换模式）
+func (s *Ruyi) ReplaceConverters(converters ...contract.Converter) error {
+	return s.converterRegister.Replace(converters...)
+}
+
+// UnregisterConverter 注销转换器
+func (s *Ruyi) UnregisterConverter(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) error {
+	return s.converterRegister.Unregister(ctx, kind, from, to)
 }
 
 // GetDescription 获取 Ruyi 的描述信息（彩蛋函数）
@@ -137,77 +214,192 @@ func (s *Ruyi) Shrink() (int32, error) {
 	return atomic.AddInt32(&s.size, -1), nil
 }
 
-//// CanConvert 判断是否存在支持指定转换的 Converter（核心功能）
-////
-//// 参数:
-////   - ctx: 上下文，用于控制超时、取消等
-////   - kind: 转换类型（Kind），例如文件、货币、时间等
-////   - from: 源 Concept 名称
-////   - to: 目标 Concept 名称
-////
-//// 返回值:
-////   - bool: 如果存在对应的 Converter 返回 true，否则返回 false
-////
-//// 说明:
-////
-////	此函数用于能力探测（Capability Check），可在调用 Convert 前判断是否支持某种转换。
-//func (s *Ruyi) CanConvert(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) bool {
-//	converter := s.converterRegister.Find(ctx, kind, from, to)
-//	return converter != nil
-//}
-//
-//// Convert 通用转换函数（核心功能）
-////
-//// 功能说明:
-////
-////	Convert 是 Ruyi 框架的核心方法，用于执行各种 Concept 间的数据转换。
-////	它统一处理不同 Kind 的转换逻辑，例如文件、货币、时间、数字等。
-////	函数只负责调用注册的 Converter 并返回原始结果，由外层封装方法负责类型断言与边界安全检查。
-////
-//// 参数:
-////   - ctx: 上下文对象，可用于控制超时、取消等操作。
-////   - kind: 转换类型（Kind），例如 File、Currency、Time、Number 等。
-////   - fromName: 源 Concept 名称，标识待转换的数据类型。
-////   - toName: 目标 Concept 名称，标识转换后的数据类型。
-////   - fromData: 待转换的数据，统一使用 []byte 表示。
-////
-//// 返回值:
-////   - toData: 转换后的数据，具体类型由外层封装方法决定。
-////   - err: 转换失败时返回的错误，包括找不到转换器或执行失败等。
-////
-//// 使用说明:
-////
-////	1、调用前可通过 CanConvert 进行能力探测。
-////	2、执行转换时，会通过注册器查找指定 kind、from -> to 的 Converter 并调用其 Convert 方法。
-////	3、转换成功后返回 Converter 输出，由外层封装方法进行类型断言。
-////	4、不同 Kind 的数据处理方式不同，调用方应使用对应封装方法。
-//func (s *Ruyi) Convert(
-//	ctx context.Context,
-//	kind contract.Kind,
-//	fromName contract.ConceptName,
-//	toName contract.ConceptName,
-//	fromData []byte,
-//) (toData []byte, err error) {
-//	// 查找对应 Converter
-//	converter := s.converterRegister.Find(ctx, kind, fromName, toName)
-//	if converter == nil {
-//		return nil, exception.Wrapf(
-//			exception.ErrNoSupportedConverter,
-//			"converter not found for kind=%s: %s -> %s",
-//			kind, fromName, toName,
-//		)
-//	}
-//
-//	// 调用 Converter 执行转换
-//	out, err := converter.Convert(ctx, fromData)
-//	if err != nil {
-//		return nil, exception.Wrapf(
-//			exception.Join(err, exception.ErrConvertFailed),
-//			"conversion failed for kind=%s: %s -> %s",
-//			kind, fromName, toName,
-//		)
-//	}
-//
-//	// 返回原始输出，由外层封装方法做类型断言
-//	return out, nil
-//}
+// CanConvert 判断是否存在支持指定转换的 Converter（核心功能）
+//
+// 参数:
+//   - ctx: 上下文，用于控制超时、取消等
+//   - kind: 转换类型（Kind），例如文件、货币、时间等
+//   - from: 源 Concept 名称
+//   - to: 目标 Concept 名称
+//
+// 返回值:
+//   - bool: 如果存在对应的 Converter 返回 true，否则返回 false
+//
+// 说明:
+//
+//	此函数用于能力探测（Capability Check），可在调用 Convert 前判断是否支持某种转换。
+func (s *Ruyi) CanConvert(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) bool {
+	converter := s.findConverter(ctx, kind, from, to)
+	return converter != nil
+}
+
+// Convert 通用转换函数（核心功能）
+//
+// 功能说明:
+//
+//	Convert 是 Ruyi 框架的核心方法，用于执行各种 Concept 间的数据转换。
+//	它将「查找 Converter」与「执行转换」合并为一次调用，统一处理不同 Kind 的转换逻辑。
+//
+// 参数:
+//   - ctx: 上下文对象，可用于控制超时、取消等操作。
+//   - kind: 转换类型（Kind），例如 File、Currency、Time、Number 等。
+//   - from: 源 Concept 名称，标识待转换的数据类型。
+//   - to: 目标 Concept 名称，标识转换后的数据类型。
+//   - in: 待转换的数据，统一使用 []byte 表示。
+//   - params: 转换参数，透传给 Converter。
+//
+// 返回值:
+//   - out: 转换后的数据。
+//   - err: 转换失败时返回的错误，包括以下情况:
+//     1、exception.ErrNoSupportedConverter 找不到转换器
+//     2、exception.ErrConvertFailed Converter 执行出错（携带 kind、from、to 上下文）
+//     3、exception.ErrIllegalConverterParam 参数非法，可通过 contract.ParamErrors 取出明细
+//     4、exception.ErrLimitExceeded 输入超出资源限制（见 ruyi.WithLimits）
+//
+// 说明:
+//
+//	严格参数模式（ruyi.WithStrictParams 或 contract.ContextWithStrictParams）下，
+//	执行转换前会一次性校验全部参数，未声明的参数同样视为非法。
+func (s *Ruyi) Convert(
+	ctx context.Context,
+	kind contract.Kind,
+	from contract.ConceptName,
+	to contract.ConceptName,
+	in []byte,
+	params map[string]string,
+) (out []byte, err error) {
+	// 查找对应 Converter，已绑定严格参数模式与资源限制
+	converter, err := s.GetConverter(ctx, kind, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// 调用 Converter 执行转换
+	out, err = converter.Convert(ctx, in, params)
+	if err != nil {
+		return nil, wrapConvertError(err, kind, from, to)
+	}
+
+	return out, nil
+}
+
+// ConvertTo 自动探测源 Concept 的转换函数（核心功能）
+//
+// 参数:
+//   - ctx: 上下文对象，可用于控制超时、取消等操作。
+//   - kind: 转换类型（Kind）。
+//   - to: 目标 Concept 名称。
+//   - in: 待转换的数据。
+//   - params: 转换参数，透传给 Converter。
+//
+// 返回值:
+//   - out: 转换后的数据。
+//   - from: 根据内容签名探测到的源 Concept。
+//   - err: 无法识别源 Concept 时返回 exception.ErrUnrecognizedContent，其余情况与 Convert 相同。
+//
+// 说明:
+//
+//	适用于调用方无法确定（或不信任）源格式的场景，例如扩展名为 .jpg 的文件实际是 PNG。
+func (s *Ruyi) ConvertTo(
+	ctx context.Context,
+	kind contract.Kind,
+	to contract.ConceptName,
+	in []byte,
+	params map[string]string,
+) (out []byte, from contract.Concept, err error) {
+	from, ok := contract.DetectConcept(in)
+	if !ok || from.Kind() != kind {
+		return nil, contract.Concept{}, exception.NewRuyiError(exception.CodeUnrecognizedContent, nil).
+			WithMessage("detect source concept failed").
+			WithConversion(string(kind), "", string(to))
+	}
+
+	out, err = s.Convert(ctx, kind, from.Name(), to, in, params)
+	if err != nil {
+		return nil, from, err
+	}
+	return out, from, nil
+}
+
+// ConvertStream 流式通用转换函数（核心功能）
+//
+// 参数:
+//   - ctx: 上下文对象，可用于控制超时、取消等操作。
+//   - kind: 转换类型（Kind）。
+//   - from: 源 Concept 名称。
+//   - to: 目标 Concept 名称。
+//   - r: 待转换数据的读取端。
+//   - w: 转换结果的写入端，转换失败时可能已写入部分数据。
+//   - params: 转换参数，透传给 Converter。
+//
+// 返回值:
+//   - err: 转换失败时返回的错误，含义与 Convert 相同。
+//
+// 说明:
+//
+//	Converter 实现了 contract.StreamConverter 时直接流式转换；否则自动读取完整输入后调用 Convert 适配。
+func (s *Ruyi) ConvertStream(
+	ctx context.Context,
+	kind contract.Kind,
+	from contract.ConceptName,
+	to contract.ConceptName,
+	r io.Reader,
+	w io.Writer,
+	params map[string]string,
+) error {
+	// 查找对应 Converter，已绑定严格参数模式与资源限制
+	converter, err := s.GetConverter(ctx, kind, from, to)
+	if err != nil {
+		return err
+	}
+
+	// 流式执行转换
+	if err = convertStream(ctx, converter, r, w, params); err != nil {
+		return wrapConvertError(err, kind, from, to)
+	}
+
+	return nil
+}
+
+// wrapConvertError 包装转换错误为 *exception.RuyiError：保证其满足 exception.ErrConvertFailed，
+// 并携带错误码、kind、from、to 以及出错的参数名
+func wrapConvertError(err error, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) error {
+	if !exception.Is(err, exception.ErrConvertFailed) {
+		err = exception.Join(err, exception.ErrConvertFailed)
+	}
+
+	ruyiErr := exception.NewRuyiError(exception.CodeOf(err), err).
+		WithConversion(string(kind), string(from), string(to))
+	if paramErrs := contract.ParamErrors(err); len(paramErrs) > 0 {
+		ruyiErr.WithParam(paramErrs[0].Name)
+	}
+	return ruyiErr
+}
+
+// checkParams 严格参数模式下，在执行转换前校验全部参数
+//
+// 说明:
+//
+//	context 中的开关优先于实例配置；多跳转换器按路由规则逐跳校验，带 Concept 前缀的参数只校验目标跳。
+func (s *Ruyi) checkParams(ctx context.Context, converter contract.Converter, params map[string]string) error {
+	strict, exist := contract.StrictParamsFromContext(ctx)
+	if !exist {
+		strict = s.strictParams
+	}
+	if !strict {
+		return nil
+	}
+
+	if chain, ok := converter.(*chainConverter); ok {
+		return chain.checkParams(params)
+	}
+	return contract.NewConverterParams(converter.Params()...).CheckParams(params, true)
+}
+
+// withLimits 为 context 注入实例的资源限制，context 中已设置时保持不变
+func (s *Ruyi) withLimits(ctx context.Context) context.Context {
+	if _, exist := contract.LimitsFromContext(ctx); exist {
+		return ctx
+	}
+	return contract.ContextWithLimits(ctx, s.limits)
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// convertStream 以流式方式执行转换
+//
+// 说明:
+//
+//	转换器实现了 contract.StreamConverter 时直接调用其 ConvertStream；
+//	否则读取完整输入后调用 Convert，再将结果写入 w。
+func convertStream(ctx context.Context, converter contract.Converter, r io.Reader, w io.Writer, params map[string]string) error {
+	if streamConverter, ok := converter.(contract.StreamConverter); ok {
+		return streamConverter.ConvertStream(ctx, r, w, params)
+	}
+
+	in, err := io.ReadAll(r)
+	if err != nil {
+		return exception.Wrapf(exception.Join(exception.ErrConvertFailed, err), "read input failed")
+	}
+
+	out, err := converter.Convert(ctx, in, params)
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(out); err != nil {
+		return exception.Wrapf(exception.Join(exception.ErrConvertFailed, err), "write output failed")
+	}
+	return nil
+}
+
+// limitReader 读取字节数超出 contract.Limits.MaxInputBytes 时返回 exception.ErrLimitExceeded
+//
+// 说明:
+//
+//	部分解码器会将读取错误转为字符串，丢失错误链，因此超限错误会被记录下来，由 Err 取回。
+//	转换器从网络、管道等可能阻塞的 Reader 读取时，由单独的 goroutine 代为执行读取（见 converter 包的 ctxReader），
+//	因此 Read 与 Err 可能在不同的 goroutine 中执行。
+type limitReader struct {
+	r      io.Reader
+	limits contract.Limits
+	n      int64
+
+	mu  sync.Mutex // 保护 err：ctx 结束后转换器不再等待阻塞中的读取，该读取可能在 Err 被调用时才返回
+	err error
+}
+
+// newLimitReader 创建 limitReader
+func newLimitReader(r io.Reader, limits contract.Limits) *limitReader {
+	return &limitReader{r: r, limits: limits}
+}
+
+func (s *limitReader) Read(p []byte) (int, error) {
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+	n, err := s.r.Read(p)
+	s.n += int64(n)
+	if limitErr := s.limits.CheckInputBytes(s.n); limitErr != nil {
+		s.mu.Lock()
+		s.err = limitErr
+		s.mu.Unlock()
+		return n, limitErr
+	}
+	return n, err
+}
+
+// Err 返回超限错误，未超限时返回 nil
+func (s *limitReader) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
@@ -1,22 +1,110 @@
 package internal
 
 import (
+	"fmt"
+
 	"github.com/google/wire"
+	"github.com/wukong-app/ruyi/internal/core"
 	"github.com/wukong-app/ruyi/internal/domain/file/image/converter"
 	"github.com/wukong-app/ruyi/internal/engine"
 	"github.com/wukong-app/ruyi/internal/register"
 	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
 )
 
 // providerSet combines all dependencies for ruyi
 var providerSet = wire.NewSet(
+	core.NewOptions,               // 实例配置
 	ProvideConverters,             // 所有 Converter
 	register.NewConverterRegistry, // Converter 注册中心
 	engine.NewRuyi,                // Ruyi 引擎
 )
 
 // ProvideConverters 生成所有转换器列表，供 ConverterRegistry 初始化使用
-func ProvideConverters() []contract.Converter {
+//
+// 说明:
+//
+//	在内置转换器的基础上依次应用配置：排除（Excluded）-> 替换（Overrides）-> 追加（Converters）。
+//	排除或替换的目标不存在、重复替换、追加的转换器与已有转换器重复时，返回 exception.ErrConverterConflict。
+func ProvideConverters(options *core.Options) ([]contract.Converter, error) {
+	var (
+		converters = builtinConverters()
+		index      = make(map[string]int, len(converters)) // converterKey -> converters 下标
+	)
+	for i, c := range converters {
+		index[converterKey(c)] = i
+	}
+
+	// 1. 排除
+	excluded := make(map[int]bool)
+	for _, key := range options.Excluded {
+		from, ok := contract.NormalizeConcept(key.From)
+		if !ok {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "exclude converter failed: unknown concept %s", key.From)
+		}
+		to, ok := contract.NormalizeConcept(key.To)
+		if !ok {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "exclude converter failed: unknown concept %s", key.To)
+		}
+		i, exist := index[newConverterKey(from.Kind(), from.Name(), to.Name())]
+		if !exist {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "exclude converter failed: no built-in converter %s -> %s", from.Name(), to.Name())
+		}
+		excluded[i] = true
+	}
+
+	// 2. 替换
+	overridden := make(map[int]bool)
+	for _, c := range options.Overrides {
+		if c == nil {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "override converter failed: converter is nil")
+		}
+		key := converterKey(c)
+		i, exist := index[key]
+		if !exist || excluded[i] {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "override converter failed: no built-in converter %s, use WithConverters to add it", key)
+		}
+		if overridden[i] {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "override converter failed: %s is overridden more than once", key)
+		}
+		overridden[i] = true
+		converters[i] = c
+	}
+
+	// 3. 追加
+	result := make([]contract.Converter, 0, len(converters)+len(options.Converters))
+	for i, c := range converters {
+		if !excluded[i] {
+			result = append(result, c)
+		}
+	}
+	for _, c := range options.Converters {
+		if c == nil {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "add converter failed: converter is nil")
+		}
+		key := converterKey(c)
+		if i, exist := index[key]; exist && !excluded[i] {
+			return nil, exception.Wrapf(exception.ErrConverterConflict, "add converter failed: %s already exists, use WithOverride to replace it", key)
+		}
+		index[key] = -1 // 标记为已存在，检测自定义转换器之间的重复
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
+// converterKey 生成转换器唯一标识
+func converterKey(c contract.Converter) string {
+	return newConverterKey(c.From().Kind(), c.From().Name(), c.To().Name())
+}
+
+// newConverterKey 生成转换器唯一标识：kind_from_to
+func newConverterKey(kind contract.Kind, from contract.ConceptName, to contract.ConceptName) string {
+	return fmt.Sprintf("%s_%s_%s", kind, from, to)
+}
+
+// builtinConverters 内置转换器列表
+func builtinConverters() []contract.Converter {
 	return []contract.Converter{
 		converter.NewBMPToPNGConverter(),
 		converter.NewBMPToJPEGConverter(),
@@ -30,6 +118,12 @@ func ProvideConverters() []contract.Converter {
 		converter.NewPNGToTIFFConverter(),
 		//converter.NewPNGToWEBPConverter(),
 		converter.NewPNGToICOConverter(),
+		converter.NewJPEGToICOConverter(),
+		converter.NewSVGToICOConverter(),
+		converter.NewPNGToIconBundleConverter(),
+		converter.NewSVGToIconBundleConverter(),
+		converter.NewGIFToFramesConverter(),
+		converter.NewAPNGToFramesConverter(),
 		//converter.NewPNGToHEICConverter(),
 		converter.NewJPEGToPNGConverter(),
 		converter.NewJPEGToSVGConverter(),
@@ -41,5 +135,17 @@ func ProvideConverters() []contract.Converter {
 		converter.NewTIFFToJPEGConverter(),
 		converter.NewWEBPToPNGConverter(),
 		converter.NewWEBPToJPEGConverter(),
+		converter.NewGIFToAPNGConverter(),
+		converter.NewAPNGToGIFConverter(),
+		converter.NewAPNGToPNGConverter(),
+		converter.NewPNGToAPNGConverter(),
+		// 同格式重新编码（WEBP、HEIC 暂无编码器）
+		converter.NewPNGToPNGConverter(),
+		converter.NewJPEGToJPEGConverter(),
+		converter.NewGIFToGIFConverter(),
+		converter.NewBMPToBMPConverter(),
+		converter.NewTIFFToTIFFConverter(),
+		converter.NewICOToICOConverter(),
+		converter.NewAPNGToAPNGConverter(),
 	}
 }
@@ -3,6 +3,8 @@ package register
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/wukong-app/ruyi/internal/core"
 	"github.com/wukong-app/ruyi/pkg/contract"
@@ -21,7 +23,14 @@ func NewConverterRegistry(converters []contract.Converter) (core.ConverterRegist
 }
 
 // converterRegistry 转换器注册器默认实现
+//
+// 说明:
+//
+//	读写均受 mu 保护，支持在使用过程中动态注册、替换、注销转换器。
 type converterRegistry struct {
+	// mu 保护以下所有索引
+	mu sync.RWMutex
+
 	// all 所有转换器
 	all []contract.Converter
 
@@ -39,35 +48,124 @@ type converterRegistry struct {
 }
 
 // Register 注册转换器
+// 与已注册转换器或本次其他转换器重复时返回 exception.ErrConverterConflict，此时不会注册任何转换器
 // @param converters 转换器列表
 func (s *converterRegistry) Register(converters ...contract.Converter) error {
 	if len(converters) == 0 {
 		return nil
 	}
 
-	var (
-		distinct       = make(map[string]struct{})
-		genDistinctKey = func(converter contract.Converter) string {
-			return fmt.Sprintf("%s_%s", converter.From().Name(), converter.To().Name())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkDistinct(converters); err != nil {
+		return err
+	}
+
+	for _, converter := range converters {
+		if s.lookup(converter.From().Kind(), converter.From().Name(), converter.To().Name()) != nil {
+			return exception.Wrapf(exception.ErrConverterConflict, "duplicate converter: %s, use replace mode to replace it", distinctKey(converter))
 		}
-	)
+	}
+
+	for _, converter := range converters {
+		s.add(converter)
+	}
+
+	return nil
+}
+
+// Replace 注册转换器（替换模式），已存在相同 kind、from、to 的转换器时替换之，否则新增
+// @param converters 转换器列表
+func (s *converterRegistry) Replace(converters ...contract.Converter) error {
+	if len(converters) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
+	if err := s.checkDistinct(converters); err != nil {
+		return err
+	}
+
+	replaced := false
 	for _, converter := range converters {
-		distinctKey := genDistinctKey(converter)
-		if _, exist := distinct[distinctKey]; exist {
-			return exception.Errorf("duplicate converter: %s", distinctKey)
+		old := s.lookup(converter.From().Kind(), converter.From().Name(), converter.To().Name())
+		if old == nil {
+			s.add(converter)
+			continue
 		}
+		for i, c := range s.all {
+			if c == old {
+				s.all[i] = converter
+				replaced = true
+				break
+			}
+		}
+	}
+
+	if replaced {
+		s.rebuild()
+	}
+	return nil
+}
 
-		// distinct
-		distinct[distinctKey] = struct{}{}
+// Unregister 注销转换器
+// 不存在对应转换器时返回 exception.ErrNoSupportedConverter
+func (s *converterRegistry) Unregister(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) error {
+	fromConcept, fromOk := contract.NormalizeConcept(from)
+	toConcept, toOk := contract.NormalizeConcept(to)
 
-		// save
-		s.add(converter)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var old contract.Converter
+	if fromOk && toOk {
+		old = s.lookup(kind, fromConcept.Name(), toConcept.Name())
+	}
+	if old == nil {
+		return exception.Wrapf(exception.ErrNoSupportedConverter, "unregister converter failed for kind=%s: %s -> %s", kind, from, to)
+	}
+
+	all := make([]contract.Converter, 0, len(s.all)-1)
+	for _, c := range s.all {
+		if c != old {
+			all = append(all, c)
+		}
 	}
+	s.all = all
+	s.rebuild()
+	return nil
+}
 
+// checkDistinct 检查待注册的转换器是否合法且互不重复
+func (s *converterRegistry) checkDistinct(converters []contract.Converter) error {
+	distinct := make(map[string]struct{}, len(converters))
+	for _, converter := range converters {
+		if converter == nil {
+			return exception.Wrapf(exception.ErrConverterConflict, "converter is nil")
+		}
+
+		key := distinctKey(converter)
+		if _, exist := distinct[key]; exist {
+			return exception.Wrapf(exception.ErrConverterConflict, "duplicate converter: %s", key)
+		}
+		distinct[key] = struct{}{}
+	}
 	return nil
 }
 
+// distinctKey 转换器唯一标识：kind_from_to
+func distinctKey(converter contract.Converter) string {
+	return fmt.Sprintf("%s_%s_%s", converter.From().Kind(), converter.From().Name(), converter.To().Name())
+}
+
+// lookup 根据标准名称查找转换器，调用方需持有锁
+func (s *converterRegistry) lookup(kind contract.Kind, from contract.ConceptName, to contract.ConceptName) contract.Converter {
+	return s.matrix[kind][from][to]
+}
+
 // Find 查找转换器
 func (s *converterRegistry) Find(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) contract.Converter {
 	// 标准化 name
@@ -79,7 +177,196 @@ func (s *converterRegistry) Find(ctx context.Context, kind contract.Kind, from c
 	if !ok {
 		return nil
 	}
-	return s.matrix[kind][fromConcept.Name()][toConcept.Name()]
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.lookup(kind, fromConcept.Name(), toConcept.Name())
+}
+
+// FindPath 查找多跳转换路径
+//
+// 说明:
+//
+//	在 kind 对应的转换器图（Concept 为顶点，Converter 为有向边）上执行 Dijkstra 最短路径搜索，
+//	边的代价由 contract.CostConverter 声明，未声明时为 1。代价相同时优先选择先注册的转换器。
+func (s *converterRegistry) FindPath(ctx context.Context, kind contract.Kind, from contract.ConceptName, to contract.ConceptName) []contract.Converter {
+	// 标准化 name
+	fromConcept, ok := contract.NormalizeConcept(from)
+	if !ok {
+		return nil
+	}
+	toConcept, ok := contract.NormalizeConcept(to)
+	if !ok {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		source = fromConcept.Name()
+		target = toConcept.Name()
+		graph  = s.byFrom[kind]
+	)
+	if source == target || len(graph[source]) == 0 {
+		return nil
+	}
+
+	var (
+		dist    = map[contract.ConceptName]int{source: 0}
+		prev    = make(map[contract.ConceptName]contract.Converter) // 到达某顶点的最后一条边
+		visited = make(map[contract.ConceptName]bool)
+		order   = []contract.ConceptName{source} // 顶点发现顺序，保证代价相同时结果稳定
+	)
+
+	for {
+		// 选取未访问顶点中距离最小者（距离相同时取先发现者）
+		var (
+			current contract.ConceptName
+			found   bool
+		)
+		for _, name := range order {
+			if visited[name] {
+				continue
+			}
+			if !found || dist[name] < dist[current] {
+				current, found = name, true
+			}
+		}
+		if !found || current == target {
+			break
+		}
+		visited[current] = true
+
+		// 松弛出边
+		for _, converter := range graph[current] {
+			next := converter.To().Name()
+			if visited[next] {
+				continue
+			}
+			d := dist[current] + converterCost(converter)
+			old, exist := dist[next]
+			if !exist {
+				order = append(order, next)
+			}
+			if !exist || d < old {
+				dist[next] = d
+				prev[next] = converter
+			}
+		}
+	}
+
+	if _, reachable := prev[target]; !reachable {
+		return nil
+	}
+
+	// 回溯路径
+	var path []contract.Converter
+	for name := target; name != source; {
+		converter := prev[name]
+		path = append([]contract.Converter{converter}, path...)
+		name = converter.From().Name()
+	}
+	return path
+}
+
+// Kinds 获取所有已注册转换器的种类（按名称排序）
+func (s *converterRegistry) Kinds() []contract.Kind {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kinds := make([]contract.Kind, 0, len(s.byKind))
+	for kind := range s.byKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+	return kinds
+}
+
+// List 获取指定种类下的所有直接转换器（按注册顺序）
+func (s *converterRegistry) List(kind contract.Kind) []contract.Converter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	converters := make([]contract.Converter, len(s.byKind[kind]))
+	copy(converters, s.byKind[kind])
+	return converters
+}
+
+// Targets 获取从 from 出发可达的所有目标 Concept（含多跳，按距离由近到远）
+func (s *converterRegistry) Targets(kind contract.Kind, from contract.ConceptName) []contract.Concept {
+	return s.reachable(kind, from, true)
+}
+
+// Sources 获取可到达 to 的所有源 Concept（含多跳，按距离由近到远）
+func (s *converterRegistry) Sources(kind contract.Kind, to contract.ConceptName) []contract.Concept {
+	return s.reachable(kind, to, false)
+}
+
+// reachable 在转换器图上做广度优先遍历，返回除起点外所有可达的 Concept
+// @param start 起点名称
+// @param forward true-沿 from -> to 方向遍历，false-沿 to -> from 方向遍历
+func (s *converterRegistry) reachable(kind contract.Kind, start contract.ConceptName, forward bool) []contract.Concept {
+	concept, ok := contract.NormalizeConcept(start)
+	if !ok || concept.Kind() != kind {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		edges = s.byFrom[kind]
+		next  = contract.Converter.To
+	)
+	if !forward {
+		edges = s.byTo[kind]
+		next = contract.Converter.From
+	}
+
+	var (
+		visited  = map[contract.ConceptName]bool{concept.Name(): true}
+		queue    = []contract.ConceptName{concept.Name()}
+		concepts []contract.Concept
+	)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, converter := range edges[current] {
+			c := next(converter)
+			if visited[c.Name()] {
+				continue
+			}
+			visited[c.Name()] = true
+			queue = append(queue, c.Name())
+			concepts = append(concepts, c)
+		}
+	}
+	return concepts
+}
+
+// converterCost 获取转换器代价
+func converterCost(converter contract.Converter) int {
+	if c, ok := converter.(contract.CostConverter); ok && c.Cost() > 0 {
+		return c.Cost()
+	}
+	return 1
+}
+
+// rebuild 根据 all 重建所有索引，调用方需持有写锁
+func (s *converterRegistry) rebuild() {
+	all := s.all
+	s.all = nil
+	s.byKind = nil
+	s.matrix = nil
+	s.byFrom = nil
+	s.byTo = nil
+	for _, converter := range all {
+		s.add(converter)
+	}
 }
 
 // add 添加转换器
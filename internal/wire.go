@@ -5,11 +5,12 @@ package internal
 
 import (
 	"github.com/google/wire"
+	"github.com/wukong-app/ruyi/internal/core"
 	"github.com/wukong-app/ruyi/pkg/contract"
 )
 
 // New returns a new Ruyi.
-func New() (contract.Ruyi, error) {
+func New(opts ...core.Option) (contract.Ruyi, error) {
 	panic(wire.Build(
 		providerSet,
 	))
@@ -7,6 +7,7 @@
 package internal
 
 import (
+	"github.com/wukong-app/ruyi/internal/core"
 	"github.com/wukong-app/ruyi/internal/engine"
 	"github.com/wukong-app/ruyi/internal/register"
 	"github.com/wukong-app/ruyi/pkg/contract"
@@ -15,12 +16,16 @@ import (
 // Injectors from wire.go:
 
 // New returns a new Ruyi.
-func New() (contract.Ruyi, error) {
-	v := ProvideConverters()
+func New(opts ...core.Option) (contract.Ruyi, error) {
+	options := core.NewOptions(opts)
+	v, err := ProvideConverters(options)
+	if err != nil {
+		return nil, err
+	}
 	converterRegistry, err := register.NewConverterRegistry(v)
 	if err != nil {
 		return nil, err
 	}
-	ruyi := engine.NewRuyi(converterRegistry)
+	ruyi := engine.NewRuyi(converterRegistry, options)
 	return ruyi, nil
 }
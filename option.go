@@ -0,0 +1,68 @@
+package ruyi
+
+import (
+	"github.com/wukong-app/ruyi/internal/core"
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// Option Ruyi 实例配置项，在创建转换器注册中心之前生效
+type Option = core.Option
+
+// WithConverters 额外注册自定义转换器
+//
+// 说明:
+//
+//	若与内置转换器（或其他自定义转换器）的 kind、from、to 相同，New 会返回 exception.ErrConverterConflict，
+//	如需替换内置转换器请使用 WithOverride。
+func WithConverters(converters ...contract.Converter) Option {
+	return func(o *core.Options) {
+		o.Converters = append(o.Converters, converters...)
+	}
+}
+
+// WithoutConverters 不注册 from -> to 的内置转换器
+//
+// 说明:
+//
+//	from、to 支持别名（如 jpg）。若不存在对应的内置转换器，New 会返回 exception.ErrConverterConflict。
+func WithoutConverters(from contract.ConceptName, to contract.ConceptName) Option {
+	return func(o *core.Options) {
+		o.Excluded = append(o.Excluded, core.ConverterKey{From: from, To: to})
+	}
+}
+
+// WithOverride 使用自定义转换器替换 kind、from、to 相同的内置转换器
+//
+// 说明:
+//
+//	若不存在可替换的内置转换器，或同一个 from -> to 被替换多次，New 会返回 exception.ErrConverterConflict。
+func WithOverride(converters ...contract.Converter) Option {
+	return func(o *core.Options) {
+		o.Overrides = append(o.Overrides, converters...)
+	}
+}
+
+// WithStrictParams 启用或关闭严格参数模式
+//
+// 说明:
+//
+//	严格模式下，转换前校验全部参数：必填参数缺失、参数值非法、未声明的参数（附带相近参数名建议）
+//	一并以 *contract.ParamError 返回，均满足 exception.ErrIllegalConverterParam。
+//	默认关闭，此时未声明的参数被忽略；单次调用可通过 contract.ContextWithStrictParams 覆盖。
+func WithStrictParams(strict bool) Option {
+	return func(o *core.Options) {
+		o.StrictParams = strict
+	}
+}
+
+// WithLimits 设置资源限制，替换默认的 contract.DefaultLimits()
+//
+// 说明:
+//
+//	用于防御解压炸弹等恶意输入：输入字节数、像素数（解码前根据图片头校验）、输出尺寸、SVG 元素个数，
+//	字段为 0 表示不限制，超出限制时返回 exception.ErrLimitExceeded；单次调用可通过 contract.ContextWithLimits 覆盖。
+func WithLimits(limits contract.Limits) Option {
+	return func(o *core.Options) {
+		o.Limits = &limits
+	}
+}
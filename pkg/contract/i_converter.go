@@ -1,6 +1,9 @@
 package contract
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Converter 泛型接口，定义具体 Concept 间的转换逻辑
 type Converter interface {
@@ -27,3 +30,55 @@ type Converter interface {
 	//       1、exception.ErrConvertFailed Converter 执行出错
 	Convert(ctx context.Context, in []byte, params map[string]string) (out []byte, err error)
 }
+
+// StreamConverter 可选接口，基于 io.Reader / io.Writer 的流式转换器
+//
+// 说明:
+//
+//	相比 Convert 需要一次性持有完整的输入与输出 []byte，ConvertStream 直接从 r 读取、向 w 写入，
+//	适用于大文件或 HTTP 请求体直通等场景。未实现该接口的转换器由引擎自动适配。
+type StreamConverter interface {
+	Converter
+
+	// ConvertStream 流式转换函数
+	//
+	// 参数:
+	//   - ctx: 上下文，用于控制超时、取消等
+	//   - r: 待转换数据的读取端
+	//   - w: 转换结果的写入端。转换失败时 w 中可能已写入部分数据，由调用方负责丢弃
+	//   - params: 转换参数，含义与 Convert 相同
+	//
+	// 返回值:
+	//   - err error: 转换失败时返回错误，包括以下情况:
+	//       1、exception.ErrConvertFailed Converter 执行出错
+	ConvertStream(ctx context.Context, r io.Reader, w io.Writer, params map[string]string) (err error)
+}
+
+// ChainConverter 由多个 Converter 串联而成的复合转换器（多跳转换）
+//
+// 说明:
+//
+//	当注册中心中不存在 from -> to 的直接转换器时，引擎会在转换器图中规划一条最短（代价最低）的路径，
+//	并将路径上的转换器串联为一个 ChainConverter 返回。
+//
+//	参数路由规则:
+//	  1、不带前缀的参数（如 width）只会传给路径上最后一个声明了该参数的转换器；
+//	  2、带目标 Concept 前缀的参数（如 png.width）只会传给目标为该 Concept 的那一跳。
+type ChainConverter interface {
+	Converter
+
+	// Path 返回转换路径上的各个转换器（按执行顺序），返回的切片对于只读使用是安全的。
+	Path() []Converter
+}
+
+// CostConverter 可选接口，声明转换器执行一次转换的代价，供多跳路径规划使用
+//
+// 说明:
+//
+//	未实现该接口的转换器代价默认为 1，即路径规划退化为「最少跳数」。
+type CostConverter interface {
+	Converter
+
+	// Cost 返回转换代价，必须为正整数
+	Cost() int
+}
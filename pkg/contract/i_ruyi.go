@@ -2,6 +2,7 @@ package contract
 
 import (
 	"context"
+	"io"
 )
 
 // Ruyi 是瑞意（Ruyi）框架的顶层接口。
@@ -12,10 +13,12 @@ import (
 //	接口对外只暴露核心转换功能和能力探测功能，保证类型安全。
 //	同时提供少量彩蛋方法用于趣味展示，不影响核心业务逻辑。
 //
-// 接口分为两大部分：
+// 接口分为四大部分：
 //
 //	1、核心功能：能力探测与执行转换。
-//	2、彩蛋功能：趣味性接口，例如获取描述、调整尺寸。
+//	2、能力发现：列出支持的种类、概念与转换矩阵。
+//	3、运行时管理：动态注册、替换、注销转换器。
+//	4、彩蛋功能：趣味性接口，例如获取描述、调整尺寸。
 type Ruyi interface {
 	// -------------------------------
 	// 核心功能
@@ -29,11 +32,133 @@ type Ruyi interface {
 	//   - to: 目标 ConceptName
 	//
 	// 返回值:
-	//   - Converter: 转换器
+	//   - Converter: 转换器。不存在直接转换器时，返回由多个转换器串联而成的 ChainConverter，
+	//     可通过类型断言 contract.ChainConverter 查看所选路径
 	//   - error: 获取失败时返回错误，包括以下情况:
 	//     		1. exception.ErrNoSupportedConverter 找不到转换器
 	GetConverter(ctx context.Context, kind Kind, from ConceptName, to ConceptName) (Converter, error)
 
+	// CanConvert 能力探测，判断是否支持指定的转换
+	// 参数:
+	//   - ctx: 上下文，用于控制超时、取消等
+	//   - kind: 转换类型（Kind）
+	//   - from: 源 ConceptName
+	//   - to: 目标 ConceptName
+	//
+	// 返回值:
+	//   - bool: 支持返回 true，否则返回 false
+	CanConvert(ctx context.Context, kind Kind, from ConceptName, to ConceptName) bool
+
+	// Convert 一次性完成「查找 Converter + 执行转换」
+	// 参数:
+	//   - ctx: 上下文，用于控制超时、取消等
+	//   - kind: 转换类型（Kind）
+	//   - from: 源 ConceptName
+	//   - to: 目标 ConceptName
+	//   - in: 待转换的数据
+	//   - params: 转换参数，透传给 Converter
+	//
+	// 返回值:
+	//   - out: 转换后的数据
+	//   - err: 转换失败时返回错误，包括以下情况:
+	//     		1. exception.ErrNoSupportedConverter 找不到转换器
+	//     		2. exception.ErrConvertFailed 转换执行失败，错误信息携带 kind、from、to
+	Convert(ctx context.Context, kind Kind, from ConceptName, to ConceptName, in []byte, params map[string]string) (out []byte, err error)
+
+	// ConvertTo 根据内容自动探测源 Concept（见 DetectConcept），再转换为目标 Concept
+	// 参数:
+	//   - ctx: 上下文，用于控制超时、取消等
+	//   - kind: 转换类型（Kind）
+	//   - to: 目标 ConceptName
+	//   - in: 待转换的数据
+	//   - params: 转换参数，透传给 Converter
+	//
+	// 返回值:
+	//   - out: 转换后的数据
+	//   - from: 探测到的源 Concept
+	//   - err: 转换失败时返回错误，包括以下情况:
+	//     		1. exception.ErrUnrecognizedContent 无法识别源 Concept，或其 Kind 与 kind 不符
+	//     		2. 其余情况与 Convert 相同
+	ConvertTo(ctx context.Context, kind Kind, to ConceptName, in []byte, params map[string]string) (out []byte, from Concept, err error)
+
+	// ConvertStream 流式版本的 Convert，从 r 读取待转换数据，将结果写入 w
+	// 参数:
+	//   - ctx: 上下文，用于控制超时、取消等
+	//   - kind: 转换类型（Kind）
+	//   - from: 源 ConceptName
+	//   - to: 目标 ConceptName
+	//   - r: 待转换数据的读取端
+	//   - w: 转换结果的写入端，转换失败时可能已写入部分数据
+	//   - params: 转换参数，透传给 Converter
+	//
+	// 返回值:
+	//   - err: 转换失败时返回错误，含义与 Convert 相同
+	//
+	// 说明:
+	//   Converter 未实现 StreamConverter 时，引擎会自动读取完整输入后调用 Convert 适配。
+	ConvertStream(ctx context.Context, kind Kind, from ConceptName, to ConceptName, r io.Reader, w io.Writer, params map[string]string) (err error)
+
+	// -------------------------------
+	// 能力发现
+	// -------------------------------
+
+	// ListKinds 获取所有已注册转换器的种类（按名称排序）
+	ListKinds() []Kind
+
+	// ListConcepts 获取指定种类下的所有已知概念（按注册顺序）
+	// 参数:
+	//   - kind: 转换类型（Kind）
+	ListConcepts(kind Kind) []Concept
+
+	// ListConverters 获取指定种类下的所有直接转换器（按注册顺序，不含多跳组合）
+	// 参数:
+	//   - kind: 转换类型（Kind）
+	ListConverters(kind Kind) []Converter
+
+	// TargetsFrom 获取源概念可以转换到的所有目标概念（含多跳路径，按跳数由近到远）
+	// 参数:
+	//   - kind: 转换类型（Kind）
+	//   - from: 源 ConceptName
+	TargetsFrom(kind Kind, from ConceptName) []Concept
+
+	// SourcesTo 获取可以转换到目标概念的所有源概念（含多跳路径，按跳数由近到远）
+	// 参数:
+	//   - kind: 转换类型（Kind）
+	//   - to: 目标 ConceptName
+	SourcesTo(kind Kind, to ConceptName) []Concept
+
+	// -------------------------------
+	// 运行时管理（并发安全，可在使用过程中调用）
+	// -------------------------------
+
+	// RegisterConverters 注册转换器
+	// 参数:
+	//   - converters: 转换器列表
+	//
+	// 返回值:
+	//   - error: 与已注册的转换器（或本次的其他转换器）kind、from、to 相同时返回 exception.ErrConverterConflict，
+	//     此时不会注册任何转换器
+	RegisterConverters(converters ...Converter) error
+
+	// ReplaceConverters 注册转换器（替换模式）
+	// 参数:
+	//   - converters: 转换器列表，已存在相同 kind、from、to 的转换器时替换之，否则新增
+	//
+	// 返回值:
+	//   - error: 本次的转换器之间重复时返回 exception.ErrConverterConflict
+	ReplaceConverters(converters ...Converter) error
+
+	// UnregisterConverter 注销转换器
+	// 参数:
+	//   - ctx: 上下文
+	//   - kind: 转换类型（Kind）
+	//   - from: 源 ConceptName
+	//   - to: 目标 ConceptName
+	//
+	// 返回值:
+	//   - error: 不存在对应转换器时返回 exception.ErrNoSupportedConverter
+	UnregisterConverter(ctx context.Context, kind Kind, from ConceptName, to ConceptName) error
+
 	// -------------------------------
 	// 彩蛋功能（趣味展示，不影响核心逻辑）
 	// -------------------------------
@@ -0,0 +1,112 @@
+package contract
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// namedColors 支持的颜色名称
+var namedColors = map[string]color.NRGBA{
+	"transparent": {},
+	"black":       {A: 0xFF},
+	"white":       {R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+	"red":         {R: 0xFF, A: 0xFF},
+	"green":       {G: 0x80, A: 0xFF},
+	"lime":        {G: 0xFF, A: 0xFF},
+	"blue":        {B: 0xFF, A: 0xFF},
+	"yellow":      {R: 0xFF, G: 0xFF, A: 0xFF},
+	"cyan":        {G: 0xFF, B: 0xFF, A: 0xFF},
+	"magenta":     {R: 0xFF, B: 0xFF, A: 0xFF},
+	"gray":        {R: 0x80, G: 0x80, B: 0x80, A: 0xFF},
+	"grey":        {R: 0x80, G: 0x80, B: 0x80, A: 0xFF},
+	"silver":      {R: 0xC0, G: 0xC0, B: 0xC0, A: 0xFF},
+	"orange":      {R: 0xFF, G: 0xA5, A: 0xFF},
+	"purple":      {R: 0x80, B: 0x80, A: 0xFF},
+	"navy":        {B: 0x80, A: 0xFF},
+}
+
+// ParseColor 解析颜色
+//
+// 支持以下格式（不区分大小写）:
+//   - 十六进制: #rgb、#rgba、#rrggbb、#rrggbbaa（# 可省略）
+//   - 函数: rgb(r, g, b)、rgba(r, g, b, a)，r/g/b 取值 0-255，a 取值 0-1
+//   - 名称: white、black、transparent、red 等常用颜色名
+func ParseColor(value string) (color.NRGBA, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if c, ok := namedColors[v]; ok {
+		return c, nil
+	}
+
+	if args, ok := cutFunc(v, "rgba"); ok {
+		return parseRGBFunc(value, args, true)
+	}
+	if args, ok := cutFunc(v, "rgb"); ok {
+		return parseRGBFunc(value, args, false)
+	}
+
+	return parseHexColor(value, strings.TrimPrefix(v, "#"))
+}
+
+// cutFunc 解析形如 name(args) 的函数调用，返回参数部分
+func cutFunc(v string, name string) (args string, ok bool) {
+	if !strings.HasPrefix(v, name+"(") || !strings.HasSuffix(v, ")") {
+		return "", false
+	}
+	return v[len(name)+1 : len(v)-1], true
+}
+
+// parseRGBFunc 解析 rgb()/rgba() 参数
+func parseRGBFunc(value string, args string, withAlpha bool) (color.NRGBA, error) {
+	parts := strings.Split(args, ",")
+	if (withAlpha && len(parts) != 4) || (!withAlpha && len(parts) != 3) {
+		return color.NRGBA{}, exception.Errorf("invalid color %q", value)
+	}
+
+	var rgb [3]uint8
+	for i := 0; i < 3; i++ {
+		n, err := strconv.ParseUint(strings.TrimSpace(parts[i]), 10, 8)
+		if err != nil {
+			return color.NRGBA{}, exception.Errorf("invalid color %q: channel must be an integer in range [0, 255]", value)
+		}
+		rgb[i] = uint8(n)
+	}
+
+	alpha := uint8(0xFF)
+	if withAlpha {
+		a, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+		if err != nil || a < 0 || a > 1 {
+			return color.NRGBA{}, exception.Errorf("invalid color %q: alpha must be a number in range [0, 1]", value)
+		}
+		alpha = uint8(a*0xFF + 0.5)
+	}
+
+	return color.NRGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: alpha}, nil
+}
+
+// parseHexColor 解析十六进制颜色（不含 #）
+func parseHexColor(value string, hex string) (color.NRGBA, error) {
+	// 短格式展开：rgb -> rrggbb，rgba -> rrggbbaa
+	if len(hex) == 3 || len(hex) == 4 {
+		var sb strings.Builder
+		for _, ch := range hex {
+			sb.WriteRune(ch)
+			sb.WriteRune(ch)
+		}
+		hex = sb.String()
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.NRGBA{}, exception.Errorf("invalid color %q", value)
+	}
+
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, exception.Errorf("invalid color %q", value)
+	}
+	return color.NRGBA{R: uint8(n >> 24), G: uint8(n >> 16), B: uint8(n >> 8), A: uint8(n)}, nil
+}
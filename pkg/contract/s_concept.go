@@ -1,16 +1,27 @@
 package contract
 
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
 // 内置概念
 var (
-	png  = newConcept(Png, File)
-	jpeg = newConcept(Jpeg, File, Jpg, Jpe)
-	svg  = newConcept(Svg, File)
-	gif  = newConcept(Gif, File)
-	bmp  = newConcept(Bmp, File, Dib)
-	tiff = newConcept(Tiff, File, Tif)
-	webp = newConcept(Webp, File)
-	heic = newConcept(Heic, File, Heif)
-	ico  = newConcept(Ico, File)
+	png  = newConcept(Png, File, ConceptMeta{MIMETypes: []string{"image/png"}, Extensions: []string{".png"}})
+	jpeg = newConcept(Jpeg, File, ConceptMeta{MIMETypes: []string{"image/jpeg", "image/pjpeg"}, Extensions: []string{".jpg", ".jpeg", ".jpe"}}, Jpg, Jpe)
+	svg  = newConcept(Svg, File, ConceptMeta{MIMETypes: []string{"image/svg+xml"}, Extensions: []string{".svg"}})
+	gif  = newConcept(Gif, File, ConceptMeta{MIMETypes: []string{"image/gif"}, Extensions: []string{".gif"}})
+	bmp  = newConcept(Bmp, File, ConceptMeta{MIMETypes: []string{"image/bmp", "image/x-bmp", "image/x-ms-bmp"}, Extensions: []string{".bmp", ".dib"}}, Dib)
+	tiff = newConcept(Tiff, File, ConceptMeta{MIMETypes: []string{"image/tiff", "image/tiff-fx"}, Extensions: []string{".tiff", ".tif"}}, Tif)
+	webp = newConcept(Webp, File, ConceptMeta{MIMETypes: []string{"image/webp"}, Extensions: []string{".webp"}})
+	heic = newConcept(Heic, File, ConceptMeta{MIMETypes: []string{"image/heic", "image/heif", "image/heic-sequence", "image/heif-sequence"}, Extensions: []string{".heic", ".heif"}}, Heif)
+	ico  = newConcept(Ico, File, ConceptMeta{MIMETypes: []string{"image/vnd.microsoft.icon", "image/x-icon"}, Extensions: []string{".ico"}})
+	apng = newConcept(Apng, File, ConceptMeta{MIMETypes: []string{"image/apng", "image/vnd.mozilla.apng"}, Extensions: []string{".apng"}})
+
+	// iconBundle 的内容是 ZIP，但不声明通用的 application/zip 与 .zip，以免任意 ZIP 都被识别为图标包，或与自定义的压缩包概念冲突
+	iconBundle = newConcept(IconBundle, File, ConceptMeta{MIMETypes: []string{"application/vnd.ruyi.icon-bundle+zip"}})
+	frames     = newConcept(Frames, File, ConceptMeta{MIMETypes: []string{"application/vnd.ruyi.frames+zip"}})
 )
 
 // Concept 概念
@@ -21,17 +32,64 @@ type Concept struct {
 	kind Kind
 	// aliases 别名
 	aliases []ConceptName
+	// meta 元数据
+	meta ConceptMeta
+}
+
+// ConceptMeta 概念元数据
+type ConceptMeta struct {
+	// MIMETypes MIME 类型，第一个为标准类型，例如 image/png
+	MIMETypes []string
+	// Extensions 文件扩展名（含前导点），第一个为标准扩展名，例如 .png
+	Extensions []string
 }
 
-// newConcept 创建概念
-func newConcept(name ConceptName, kind Kind, aliases ...ConceptName) Concept {
-	concept := Concept{
+// newConcept 创建内置概念，与已有概念冲突时 panic
+func newConcept(name ConceptName, kind Kind, meta ConceptMeta, aliases ...ConceptName) Concept {
+	concept, err := RegisterConceptWithMeta(name, kind, meta, aliases...)
+	if err != nil {
+		panic(err)
+	}
+	return concept
+}
+
+// RegisterConcept 注册自定义概念（并发安全）
+//
+// 参数:
+//   - name: 概念名称，例如 avif
+//   - kind: 概念所属种类，可以是内置种类（如 File），也可以是自定义种类
+//   - aliases: 别名，例如 jpg 之于 jpeg
+//
+// 返回值:
+//   - concept: 注册成功的概念，可用于实现自定义 Converter 的 From / To
+//   - err: name 或 alias 为空、与已有概念的 name 或 alias 冲突时返回 exception.ErrConceptConflict
+//
+// 说明:
+//
+//	注册后 NormalizeConcept（以及基于它的转换器查找）即可识别该概念。
+func RegisterConcept(name ConceptName, kind Kind, aliases ...ConceptName) (concept Concept, err error) {
+	return RegisterConceptWithMeta(name, kind, ConceptMeta{}, aliases...)
+}
+
+// RegisterConceptWithMeta 注册带元数据（MIME 类型、扩展名）的自定义概念（并发安全）
+//
+// 说明:
+//
+//	与 RegisterConcept 相同，此外 MIME 类型、扩展名与已有概念冲突时同样返回 exception.ErrConceptConflict。
+func RegisterConceptWithMeta(name ConceptName, kind Kind, meta ConceptMeta, aliases ...ConceptName) (concept Concept, err error) {
+	copyAliases := make([]ConceptName, len(aliases))
+	copy(copyAliases, aliases)
+
+	concept = Concept{
 		name:    name,
 		kind:    kind,
-		aliases: aliases,
+		aliases: copyAliases,
+		meta:    meta.normalize(),
 	}
-	_conceptCache.put(concept) // 加入缓存
-	return concept
+	if err = _conceptCache.put(concept); err != nil {
+		return Concept{}, err
+	}
+	return concept, nil
 }
 
 // NormalizeConcept 根据name 或 alias 获取概念
@@ -39,6 +97,28 @@ func NormalizeConcept(name ConceptName) (concept Concept, exist bool) {
 	return _conceptCache.getFromByNameOrAliasesMap(name)
 }
 
+// ConceptByMIME 根据 MIME 类型获取概念
+// @param mimeType MIME 类型，可带参数，例如 Content-Type 头 "image/svg+xml; charset=utf-8"
+func ConceptByMIME(mimeType string) (concept Concept, exist bool) {
+	return _conceptCache.getFromMIMEMap(normalizeMIMEType(mimeType))
+}
+
+// ConceptByExtension 根据文件扩展名获取概念
+// @param ext 扩展名或文件名，例如 "png"、".PNG"、"photo.png"
+func ConceptByExtension(ext string) (concept Concept, exist bool) {
+	return _conceptCache.getFromExtensionMap(normalizeExtension(ext))
+}
+
+// ConceptsByKind 获取指定 kind 下的所有概念（按注册顺序）
+func ConceptsByKind(kind Kind) []Concept {
+	return _conceptCache.getFromByKindMap(kind)
+}
+
+// Kinds 获取所有已注册概念的种类（含自定义种类，按名称排序）
+func Kinds() []Kind {
+	return _conceptCache.kinds()
+}
+
 func (s Concept) Name() ConceptName {
 	return s.name
 }
@@ -53,6 +133,55 @@ func (s Concept) Aliases() []ConceptName {
 	return copyAliases
 }
 
+// MIMETypes 获取所有 MIME 类型，第一个为标准类型
+func (s Concept) MIMETypes() []string {
+	copyMIMETypes := make([]string, len(s.meta.MIMETypes))
+	copy(copyMIMETypes, s.meta.MIMETypes)
+	return copyMIMETypes
+}
+
+// MIMEType 获取标准 MIME 类型，未声明时返回空字符串
+func (s Concept) MIMEType() string {
+	if len(s.meta.MIMETypes) == 0 {
+		return ""
+	}
+	return s.meta.MIMETypes[0]
+}
+
+// Extensions 获取所有文件扩展名（含前导点），第一个为标准扩展名
+func (s Concept) Extensions() []string {
+	copyExtensions := make([]string, len(s.meta.Extensions))
+	copy(copyExtensions, s.meta.Extensions)
+	return copyExtensions
+}
+
+// Extension 获取标准文件扩展名（含前导点），未声明时返回空字符串
+func (s Concept) Extension() string {
+	if len(s.meta.Extensions) == 0 {
+		return ""
+	}
+	return s.meta.Extensions[0]
+}
+
+// normalize 标准化元数据：MIME 类型转小写并去掉参数，扩展名转小写并补齐前导点
+func (s ConceptMeta) normalize() ConceptMeta {
+	meta := ConceptMeta{
+		MIMETypes:  make([]string, 0, len(s.MIMETypes)),
+		Extensions: make([]string, 0, len(s.Extensions)),
+	}
+	for _, mimeType := range s.MIMETypes {
+		if mimeType = normalizeMIMEType(mimeType); mimeType != "" {
+			meta.MIMETypes = append(meta.MIMETypes, mimeType)
+		}
+	}
+	for _, ext := range s.Extensions {
+		if ext = normalizeExtension(ext); ext != "" {
+			meta.Extensions = append(meta.Extensions, ext)
+		}
+	}
+	return meta
+}
+
 func PNG() Concept {
 	return png
 }
@@ -88,3 +217,37 @@ func HEIC() Concept {
 func ICO() Concept {
 	return ico
 }
+
+func APNG() Concept {
+	return apng
+}
+
+func ICONBUNDLE() Concept {
+	return iconBundle
+}
+
+func FRAMES() Concept {
+	return frames
+}
+
+// normalizeMIMEType 标准化 MIME 类型：去掉参数并转小写，非法时返回空字符串
+func normalizeMIMEType(mimeType string) string {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// normalizeExtension 标准化扩展名：支持文件名，转小写并补齐前导点
+func normalizeExtension(ext string) string {
+	ext = strings.TrimSpace(ext)
+	if e := filepath.Ext(ext); e != "" {
+		ext = e
+	}
+	ext = strings.ToLower(strings.TrimPrefix(ext, "."))
+	if ext == "" {
+		return ""
+	}
+	return "." + ext
+}
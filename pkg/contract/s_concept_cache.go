@@ -1,28 +1,91 @@
 package contract
 
+import (
+	"sort"
+	"sync"
+
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
 // 概念缓存
 var _conceptCache = newConceptCache()
 
-// conceptCache concept 索引
+// conceptCache concept 索引，读写均受 mu 保护
 type conceptCache struct {
+	// mu 保护以下所有索引
+	mu sync.RWMutex
+
 	// nameOrAliasesMap name or aliases -> concept
 	nameOrAliasesMap map[ConceptName]Concept
 
 	// kindMap kind -> concepts
 	kindMap map[Kind][]Concept
+
+	// mimeMap mime type -> concept
+	mimeMap map[string]Concept
+
+	// extensionMap extension -> concept
+	extensionMap map[string]Concept
 }
 
 func newConceptCache() *conceptCache {
 	return &conceptCache{
 		nameOrAliasesMap: make(map[ConceptName]Concept),
 		kindMap:          make(map[Kind][]Concept),
+		mimeMap:          make(map[string]Concept),
+		extensionMap:     make(map[string]Concept),
 	}
 }
 
-// put 添加概念， 若有重命直接覆盖
-func (s *conceptCache) put(concept Concept) {
+// put 添加概念
+// name、alias、MIME 类型、扩展名与已有概念冲突时返回 exception.ErrConceptConflict，此时不会添加任何索引
+func (s *conceptCache) put(concept Concept) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkConflict(concept); err != nil {
+		return err
+	}
+
 	s.putInNameOrAliasesMap(concept)
 	s.putInKindMap(concept)
+	s.putInMetaMap(concept)
+	return nil
+}
+
+// checkConflict 检查概念与已有概念（以及自身各字段之间）是否冲突，调用方需持有锁
+func (s *conceptCache) checkConflict(concept Concept) error {
+	if concept.name == "" {
+		return exception.Wrapf(exception.ErrConceptConflict, "concept name is empty")
+	}
+	if concept.kind == "" {
+		return exception.Wrapf(exception.ErrConceptConflict, "concept kind is empty: %s", concept.name)
+	}
+
+	names := make(map[ConceptName]struct{}, len(concept.aliases)+1)
+	for _, name := range append([]ConceptName{concept.name}, concept.aliases...) {
+		if name == "" {
+			return exception.Wrapf(exception.ErrConceptConflict, "concept alias is empty: %s", concept.name)
+		}
+		if _, exist := names[name]; exist {
+			return exception.Wrapf(exception.ErrConceptConflict, "duplicate name or alias %s in concept %s", name, concept.name)
+		}
+		names[name] = struct{}{}
+		if old, exist := s.nameOrAliasesMap[name]; exist {
+			return exception.Wrapf(exception.ErrConceptConflict, "name or alias %s of concept %s is already used by concept %s", name, concept.name, old.name)
+		}
+	}
+	for _, mimeType := range concept.meta.MIMETypes {
+		if old, exist := s.mimeMap[mimeType]; exist {
+			return exception.Wrapf(exception.ErrConceptConflict, "mime type %s of concept %s is already used by concept %s", mimeType, concept.name, old.name)
+		}
+	}
+	for _, ext := range concept.meta.Extensions {
+		if old, exist := s.extensionMap[ext]; exist {
+			return exception.Wrapf(exception.ErrConceptConflict, "extension %s of concept %s is already used by concept %s", ext, concept.name, old.name)
+		}
+	}
+	return nil
 }
 
 // putInByNameOrAliasesMap 添加概念到 nameOrAliasesMap
@@ -41,6 +104,9 @@ func (s *conceptCache) putInNameOrAliasesMap(concept Concept) {
 // getFromByNameOrAliasesMap 从 nameOrAliasesMap 获取概念
 // @param name 概念 name or alias
 func (s *conceptCache) getFromByNameOrAliasesMap(name ConceptName) (concept Concept, exist bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	concept, exist = s.nameOrAliasesMap[name]
 	return
 }
@@ -55,7 +121,64 @@ func (s *conceptCache) putInKindMap(concept Concept) {
 	s.kindMap[kind] = append(s.kindMap[kind], concept)
 }
 
-// getFromByKindMap 从 kindMap 获取概念
+// getFromByKindMap 从 kindMap 获取概念，返回副本
 func (s *conceptCache) getFromByKindMap(kind Kind) (concepts []Concept) {
-	return s.kindMap[kind]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	concepts = make([]Concept, len(s.kindMap[kind]))
+	copy(concepts, s.kindMap[kind])
+	return concepts
+}
+
+// kinds 获取所有种类（按名称排序）
+func (s *conceptCache) kinds() []Kind {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kinds := make([]Kind, 0, len(s.kindMap))
+	for kind := range s.kindMap {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+	return kinds
+}
+
+// putInMetaMap 添加概念到 mimeMap、extensionMap
+func (s *conceptCache) putInMetaMap(concept Concept) {
+	if s.mimeMap == nil {
+		s.mimeMap = make(map[string]Concept)
+	}
+	if s.extensionMap == nil {
+		s.extensionMap = make(map[string]Concept)
+	}
+
+	for _, mimeType := range concept.meta.MIMETypes {
+		s.mimeMap[mimeType] = concept
+	}
+	for _, ext := range concept.meta.Extensions {
+		s.extensionMap[ext] = concept
+	}
+}
+
+// getFromMIMEMap 从 mimeMap 获取概念
+// @param mimeType 标准化后的 MIME 类型
+func (s *conceptCache) getFromMIMEMap(mimeType string) (concept Concept, exist bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	concept, exist = s.mimeMap[mimeType]
+	return
+}
+
+// getFromExtensionMap 从 extensionMap 获取概念
+// @param ext 标准化后的扩展名
+func (s *conceptCache) getFromExtensionMap(ext string) (concept Concept, exist bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	concept, exist = s.extensionMap[ext]
+	return
 }
@@ -0,0 +1,153 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// svgSniffLen 探测 SVG 时最多检查的字节数
+const svgSniffLen = 1024
+
+// conceptDetector 概念探测器
+type conceptDetector struct {
+	// concept 探测成功时返回的概念
+	concept func() Concept
+	// match 根据内容签名判断是否匹配
+	match func(in []byte) bool
+}
+
+// _conceptDetectors 内置概念探测器，按顺序匹配
+var _conceptDetectors = []conceptDetector{
+	{concept: APNG, match: isAPNG}, // APNG 同样以 PNG 签名开头，需先于 PNG 匹配
+	{concept: PNG, match: isPNG},
+	{concept: JPEG, match: isJPEG},
+	{concept: GIF, match: isGIF},
+	{concept: BMP, match: isBMP},
+	{concept: TIFF, match: isTIFF},
+	{concept: WEBP, match: isWEBP},
+	{concept: HEIC, match: isHEIC},
+	{concept: ICO, match: isICO},
+	{concept: SVG, match: isSVG},
+}
+
+// DetectConcept 根据内容签名（magic number）探测数据的概念
+//
+// 参数:
+//   - in: 待探测的数据，通常只需前几百字节即可，SVG 需要前 1KB
+//
+// 返回值:
+//   - concept: 探测到的概念
+//   - exist: 是否探测成功
+func DetectConcept(in []byte) (concept Concept, exist bool) {
+	for _, detector := range _conceptDetectors {
+		if detector.match(in) {
+			return detector.concept(), true
+		}
+	}
+	return Concept{}, false
+}
+
+// isPNG \x89PNG\r\n\x1a\n
+func isPNG(in []byte) bool {
+	return bytes.HasPrefix(in, []byte("\x89PNG\r\n\x1a\n"))
+}
+
+// isAPNG PNG 签名，且 IDAT 之前存在 acTL 块（动画控制块）
+func isAPNG(in []byte) bool {
+	if !isPNG(in) {
+		return false
+	}
+	for i := 8; i+8 <= len(in); {
+		size := int(binary.BigEndian.Uint32(in[i : i+4]))
+		switch string(in[i+4 : i+8]) {
+		case "acTL":
+			return true
+		case "IDAT", "IEND":
+			return false
+		}
+		if size < 0 || size > len(in) {
+			return false
+		}
+		i += 12 + size // 长度、类型、数据、CRC
+	}
+	return false
+}
+
+// isJPEG SOI 标记 FF D8 FF
+func isJPEG(in []byte) bool {
+	return bytes.HasPrefix(in, []byte{0xFF, 0xD8, 0xFF})
+}
+
+// isGIF GIF87a / GIF89a
+func isGIF(in []byte) bool {
+	return bytes.HasPrefix(in, []byte("GIF87a")) || bytes.HasPrefix(in, []byte("GIF89a"))
+}
+
+// isBMP BM + 文件头（14 字节）+ DIB 头长度
+func isBMP(in []byte) bool {
+	return len(in) >= 18 && bytes.HasPrefix(in, []byte("BM"))
+}
+
+// isTIFF II*\0（小端） / MM\0*（大端）
+func isTIFF(in []byte) bool {
+	return bytes.HasPrefix(in, []byte("II*\x00")) || bytes.HasPrefix(in, []byte("MM\x00*"))
+}
+
+// isWEBP RIFF????WEBP
+func isWEBP(in []byte) bool {
+	return len(in) >= 12 && bytes.HasPrefix(in, []byte("RIFF")) && bytes.Equal(in[8:12], []byte("WEBP"))
+}
+
+// heicBrands HEIC/HEIF 的 ftyp 品牌
+var heicBrands = map[string]bool{
+	"heic": true, "heix": true, "hevc": true, "hevx": true,
+	"heim": true, "heis": true, "hevm": true, "hevs": true,
+}
+
+// isHEIC ISO BMFF ftyp 盒子：主品牌为 HEIC 品牌，或主品牌为 mif1/msf1 且兼容品牌中包含 HEIC 品牌
+func isHEIC(in []byte) bool {
+	if len(in) < 16 || !bytes.Equal(in[4:8], []byte("ftyp")) {
+		return false
+	}
+
+	major := string(in[8:12])
+	if heicBrands[major] {
+		return true
+	}
+	if major != "mif1" && major != "msf1" {
+		return false
+	}
+
+	size := int(binary.BigEndian.Uint32(in[0:4]))
+	if size > len(in) {
+		size = len(in)
+	}
+	// 兼容品牌从偏移 16 开始（跳过 minor_version）
+	for i := 16; i+4 <= size; i += 4 {
+		if heicBrands[string(in[i:i+4])] {
+			return true
+		}
+	}
+	return false
+}
+
+// isICO 保留字段 0、类型 1（图标）、图像数量大于 0
+func isICO(in []byte) bool {
+	return len(in) >= 6 &&
+		bytes.HasPrefix(in, []byte{0x00, 0x00, 0x01, 0x00}) &&
+		binary.LittleEndian.Uint16(in[4:6]) > 0
+}
+
+// isSVG 以 XML 标记开头，且前 1KB 内包含 <svg 标签
+func isSVG(in []byte) bool {
+	head := in
+	if len(head) > svgSniffLen {
+		head = head[:svgSniffLen]
+	}
+	head = bytes.TrimPrefix(head, []byte("\xEF\xBB\xBF")) // UTF-8 BOM
+	head = bytes.TrimLeft(head, " \t\r\n")
+	if !bytes.HasPrefix(head, []byte("<")) {
+		return false
+	}
+	return bytes.Contains(bytes.ToLower(head), []byte("<svg"))
+}
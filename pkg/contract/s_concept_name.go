@@ -9,6 +9,7 @@ type ConceptName string
 
 const (
 	Png  ConceptName = "png"
+	Apng ConceptName = "apng"
 	Jpeg ConceptName = "jpeg"
 	Jpg  ConceptName = "jpg"
 	Jpe  ConceptName = "jpe"
@@ -22,4 +23,7 @@ const (
 	Heic ConceptName = "heic"
 	Heif ConceptName = "heif"
 	Ico  ConceptName = "ico"
+
+	IconBundle ConceptName = "icon-bundle" // 网站、应用图标包（ZIP）
+	Frames     ConceptName = "frames"      // 动画逐帧图片包（ZIP，每帧一个 PNG）
 )
@@ -0,0 +1,44 @@
+package contract
+
+import "context"
+
+// strictParamsKey 严格参数模式的 context key
+type strictParamsKey struct{}
+
+// ContextWithStrictParams 返回携带严格参数模式开关的 context，用于单次调用覆盖 Ruyi 实例的配置
+//
+// 说明:
+//
+//	严格模式下，Ruyi.Convert 等方法在执行转换前校验全部参数，
+//	未声明的参数（例如拼写错误的 qualty）会返回 exception.ErrIllegalConverterParam。
+func ContextWithStrictParams(ctx context.Context, strict bool) context.Context {
+	return context.WithValue(ctx, strictParamsKey{}, strict)
+}
+
+// StrictParamsFromContext 获取 context 中的严格参数模式开关
+//
+// 返回值:
+//   - strict: 是否为严格模式
+//   - exist: context 中是否设置了该开关
+func StrictParamsFromContext(ctx context.Context) (strict bool, exist bool) {
+	strict, exist = ctx.Value(strictParamsKey{}).(bool)
+	return strict, exist
+}
+
+// limitsKey 资源限制的 context key
+type limitsKey struct{}
+
+// ContextWithLimits 返回携带资源限制的 context，用于单次调用覆盖 Ruyi 实例的配置
+func ContextWithLimits(ctx context.Context, limits Limits) context.Context {
+	return context.WithValue(ctx, limitsKey{}, limits)
+}
+
+// LimitsFromContext 获取 context 中的资源限制
+//
+// 返回值:
+//   - limits: 资源限制
+//   - exist: context 中是否设置了资源限制
+func LimitsFromContext(ctx context.Context) (limits Limits, exist bool) {
+	limits, exist = ctx.Value(limitsKey{}).(Limits)
+	return limits, exist
+}
@@ -1,24 +1,39 @@
 package contract
 
-import "github.com/wukong-app/ruyi/pkg/exception"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
 
 type ConverterParams map[string]ConverterParam
 
+// NewConverterParams 根据参数列表创建 ConverterParams
+func NewConverterParams(params ...ConverterParam) ConverterParams {
+	s := make(ConverterParams, len(params))
+	s.Append(params...)
+	return s
+}
+
 func (s ConverterParams) Append(params ...ConverterParam) {
 	for _, param := range params {
 		s[param.Name] = param
 	}
 }
 
+// CheckAndGetParams 校验参数并补齐默认值
+// 未传入或传入空字符串的参数使用默认值（空字符串不会交给 Schema 与 Check 校验）；必填参数缺失、参数值非法时返回 *ParamError（可能有多个），
+// 未声明的参数被忽略，如需拒绝请先调用 CheckParams(params, true)
 func (s ConverterParams) CheckAndGetParams(params map[string]string) (validParams map[string]string, err error) {
+	if err = s.CheckParams(params, false); err != nil {
+		return nil, err
+	}
+
 	validParams = make(map[string]string, len(s))
 	for key, paramDef := range s {
 		value := paramDef.Default
-		if v, exist := params[key]; exist {
-			err = paramDef.Check(v)
-			if err != nil {
-				return nil, exception.Wrapf(exception.Join(exception.ErrIllegalConverterParam, err), "converter param [%s=%s] check failed", key, v)
-			}
+		if v, exist := params[key]; exist && v != "" {
 			value = v
 		}
 		validParams[key] = value
@@ -26,13 +41,69 @@ func (s ConverterParams) CheckAndGetParams(params map[string]string) (validParam
 	return validParams, nil
 }
 
+// CheckParams 校验参数，返回全部参数错误
+//
+// 参数:
+//   - params: 调用方传入的参数
+//   - strict: 是否为严格模式，严格模式下未声明的参数视为错误（并给出相近参数名建议）
+//
+// 返回值:
+//   - err: 由一个或多个 *ParamError 合并而成的错误，满足 exception.ErrIllegalConverterParam；
+//     可通过 ParamErrors 取出全部参数错误。
+func (s ConverterParams) CheckParams(params map[string]string, strict bool) error {
+	var errs []error
+
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// 必填参数与参数值
+	for _, name := range names {
+		paramDef := s[name]
+		value := params[name]
+		if value == "" {
+			if paramDef.Required && paramDef.Default == "" {
+				errs = append(errs, &ParamError{Reason: ParamMissing, Name: name})
+			}
+			continue
+		}
+		if err := paramDef.Validate(value); err != nil {
+			errs = append(errs, &ParamError{Reason: ParamInvalid, Name: name, Value: value, Err: err})
+		}
+	}
+
+	// 未知参数
+	if strict {
+		unknown := make([]string, 0)
+		for key := range params {
+			if _, exist := s[key]; !exist {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			errs = append(errs, &ParamError{
+				Reason:      ParamUnknown,
+				Name:        key,
+				Value:       params[key],
+				Suggestions: suggestParamNames(key, names),
+			})
+		}
+	}
+
+	return exception.Join(errs...)
+}
+
 // ConverterParam 转换器参数规格
 type ConverterParam struct {
 	Name     string                   // 参数名
 	Desc     string                   // 参数描述
 	Default  string                   // 默认值
 	Required bool                     // 是否必填。true-是，false-否
-	Check    func(value string) error // 参数值校验函数
+	Schema   ParamSchema              // 参数值规格（类型、范围、可选值、单位）
+	Check    func(value string) error // 额外的参数值校验函数，可为空，在 Schema 校验通过后执行
 }
 
 func (s ConverterParam) Clone() ConverterParam {
@@ -41,6 +112,33 @@ func (s ConverterParam) Clone() ConverterParam {
 		Desc:     s.Desc,
 		Default:  s.Default,
 		Required: s.Required,
+		Schema:   s.Schema.Clone(),
 		Check:    s.Check,
 	}
 }
+
+// Validate 校验参数值：先按 Schema 校验，再执行 Check
+func (s ConverterParam) Validate(value string) error {
+	if err := s.Schema.Check(value); err != nil {
+		return err
+	}
+	if s.Check != nil {
+		return s.Check(value)
+	}
+	return nil
+}
+
+// SortParams 按参数名排序，返回新切片，便于稳定展示
+func SortParams(params []ConverterParam) []ConverterParam {
+	sorted := make([]ConverterParam, len(params))
+	copy(sorted, params)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// String 参数描述，例如 "quality: int [1, 100]"
+func (s ConverterParam) String() string {
+	return fmt.Sprintf("%s: %s", s.Name, s.Schema)
+}
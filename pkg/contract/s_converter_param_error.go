@@ -0,0 +1,134 @@
+package contract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// ParamErrorReason 参数错误原因
+type ParamErrorReason string
+
+const (
+	ParamMissing ParamErrorReason = "missing" // 缺少必填参数
+	ParamUnknown ParamErrorReason = "unknown" // 未知参数（仅严格模式）
+	ParamInvalid ParamErrorReason = "invalid" // 参数值非法
+)
+
+// maxParamSuggestions "did you mean" 最多给出的建议数
+const maxParamSuggestions = 3
+
+// ParamError 结构化的转换器参数错误，满足 exception.ErrIllegalConverterParam
+//
+// 说明:
+//
+//	可通过 exception.As 取出第一个参数错误，或通过 ParamErrors 取出全部参数错误。
+type ParamError struct {
+	Reason      ParamErrorReason // 错误原因
+	Name        string           // 参数名
+	Value       string           // 参数值，ParamMissing 时为空
+	Suggestions []string         // 相近的参数名，仅 ParamUnknown 时可能非空
+	Err         error            // 底层错误，仅 ParamInvalid 时非空
+}
+
+func (e *ParamError) Error() string {
+	switch e.Reason {
+	case ParamMissing:
+		return fmt.Sprintf("converter param [%s] is required", e.Name)
+	case ParamUnknown:
+		msg := fmt.Sprintf("unknown converter param [%s=%s]", e.Name, e.Value)
+		if len(e.Suggestions) > 0 {
+			msg += fmt.Sprintf(", did you mean %s?", strings.Join(e.Suggestions, " or "))
+		}
+		return msg
+	default:
+		return fmt.Sprintf("converter param [%s=%s] check failed: %v", e.Name, e.Value, e.Err)
+	}
+}
+
+// Unwrap 使 exception.Is 能够同时匹配 exception.ErrIllegalConverterParam 与底层错误
+func (e *ParamError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{exception.ErrIllegalConverterParam}
+	}
+	return []error{exception.ErrIllegalConverterParam, e.Err}
+}
+
+// ParamErrors 取出错误树中的全部参数错误
+func ParamErrors(err error) []*ParamError {
+	var result []*ParamError
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+		case *ParamError:
+			result = append(result, e)
+		case interface{ Unwrap() []error }:
+			for _, child := range e.Unwrap() {
+				walk(child)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+	walk(err)
+	return result
+}
+
+// suggestParamNames 从候选参数名中找出与 name 相近的参数名（编辑距离或前缀），按相似度排序
+func suggestParamNames(name string, candidates []string) []string {
+	type scored struct {
+		name     string
+		distance int
+	}
+
+	lower := strings.ToLower(name)
+	var matches []scored
+	for _, candidate := range candidates {
+		c := strings.ToLower(candidate)
+		d := levenshtein(lower, c)
+		if (d <= 2 && d < len(lower)) || (len(lower) >= 3 && strings.HasPrefix(c, lower)) {
+			matches = append(matches, scored{name: candidate, distance: d})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+	if len(matches) > maxParamSuggestions {
+		matches = matches[:maxParamSuggestions]
+	}
+
+	suggestions := make([]string, 0, len(matches))
+	for _, m := range matches {
+		suggestions = append(suggestions, m.name)
+	}
+	return suggestions
+}
+
+// levenshtein 编辑距离
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
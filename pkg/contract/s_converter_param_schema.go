@@ -0,0 +1,268 @@
+package contract
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// ParamType 参数值类型
+type ParamType string
+
+const (
+	ParamTypeString   ParamType = "string"   // 字符串
+	ParamTypeInt      ParamType = "int"      // 整数
+	ParamTypeFloat    ParamType = "float"    // 浮点数
+	ParamTypeBool     ParamType = "bool"     // 布尔值，取值见 strconv.ParseBool
+	ParamTypeEnum     ParamType = "enum"     // 枚举，取值见 ParamSchema.Enum
+	ParamTypeColor    ParamType = "color"    // 颜色，格式见 ParseColor
+	ParamTypeDuration ParamType = "duration" // 时长，格式见 time.ParseDuration
+)
+
+// ParamSchema 参数值规格，用于校验参数值，并可导出为 JSON Schema、CLI 帮助、UI 表单等
+type ParamSchema struct {
+	// Type 值类型，为空时视为 ParamTypeString
+	Type ParamType
+	// Min 最小值（含），仅对 int、float、duration（单位：秒）生效，nil 表示不限制
+	Min *float64
+	// Max 最大值（含），仅对 int、float、duration（单位：秒）生效，nil 表示不限制
+	Max *float64
+	// Enum 可选值，对 enum 必填，对 string 可选
+	Enum []string
+	// Unit 单位，仅用于展示，例如 px、%、deg
+	Unit string
+}
+
+// WithMin 返回设置了最小值的副本
+func (s ParamSchema) WithMin(min float64) ParamSchema {
+	s.Min = &min
+	return s
+}
+
+// WithMax 返回设置了最大值的副本
+func (s ParamSchema) WithMax(max float64) ParamSchema {
+	s.Max = &max
+	return s
+}
+
+// WithRange 返回设置了取值范围的副本
+func (s ParamSchema) WithRange(min, max float64) ParamSchema {
+	return s.WithMin(min).WithMax(max)
+}
+
+// WithEnum 返回设置了可选值的副本
+func (s ParamSchema) WithEnum(values ...string) ParamSchema {
+	s.Enum = append([]string(nil), values...)
+	return s
+}
+
+// WithUnit 返回设置了单位的副本
+func (s ParamSchema) WithUnit(unit string) ParamSchema {
+	s.Unit = unit
+	return s
+}
+
+// Clone 深拷贝
+func (s ParamSchema) Clone() ParamSchema {
+	if s.Min != nil {
+		s = s.WithMin(*s.Min)
+	}
+	if s.Max != nil {
+		s = s.WithMax(*s.Max)
+	}
+	if s.Enum != nil {
+		s = s.WithEnum(s.Enum...)
+	}
+	return s
+}
+
+// Check 按规格校验参数值，空字符串表示未设置，始终合法
+func (s ParamSchema) Check(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	switch s.Type {
+	case ParamTypeInt:
+		v, err := strconv.ParseInt(value, 10, strconv.IntSize)
+		if err != nil {
+			return exception.Errorf("param value must be an integer")
+		}
+		return s.checkRange(float64(v))
+	case ParamTypeFloat:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return exception.Errorf("param value must be a number")
+		}
+		return s.checkRange(v)
+	case ParamTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return exception.Errorf("param value must be a bool (true/false)")
+		}
+		return nil
+	case ParamTypeColor:
+		_, err := ParseColor(value)
+		return err
+	case ParamTypeDuration:
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return exception.Errorf("param value must be a duration, e.g. 500ms, 1.5s")
+		}
+		return s.checkRange(v.Seconds())
+	default: // ParamTypeString、ParamTypeEnum
+		if s.Type == ParamTypeEnum || len(s.Enum) > 0 {
+			return s.checkEnum(value)
+		}
+		return nil
+	}
+}
+
+// checkRange 校验取值范围
+func (s ParamSchema) checkRange(v float64) error {
+	if s.Min != nil && v < *s.Min {
+		return exception.Errorf("param value must be in range %s", s.rangeString())
+	}
+	if s.Max != nil && v > *s.Max {
+		return exception.Errorf("param value must be in range %s", s.rangeString())
+	}
+	return nil
+}
+
+// checkEnum 校验可选值（不区分大小写）
+func (s ParamSchema) checkEnum(value string) error {
+	for _, v := range s.Enum {
+		if strings.EqualFold(v, value) {
+			return nil
+		}
+	}
+	return exception.Errorf("param value must be one of [%s]", strings.Join(s.Enum, ", "))
+}
+
+// rangeString 取值范围描述，例如 [1, 100]、[0, +∞)
+func (s ParamSchema) rangeString() string {
+	min, max := "(-∞", "+∞)"
+	if s.Min != nil {
+		min = "[" + strconv.FormatFloat(*s.Min, 'f', -1, 64)
+	}
+	if s.Max != nil {
+		max = strconv.FormatFloat(*s.Max, 'f', -1, 64) + "]"
+	}
+	return min + ", " + max
+}
+
+// String 规格描述，用于 CLI 帮助等场景，例如 "int [1, 100]"、"enum (fit|fill)"、"int [0, +∞) px"
+func (s ParamSchema) String() string {
+	typ := s.Type
+	if typ == "" {
+		typ = ParamTypeString
+	}
+
+	parts := []string{string(typ)}
+	if s.Min != nil || s.Max != nil {
+		parts = append(parts, s.rangeString())
+	}
+	if len(s.Enum) > 0 {
+		parts = append(parts, "("+strings.Join(s.Enum, "|")+")")
+	}
+	if s.Unit != "" {
+		parts = append(parts, s.Unit)
+	}
+	return strings.Join(parts, " ")
+}
+
+// JSONSchema 导出为 JSON Schema（draft 2020-12）片段
+func (s ParamSchema) JSONSchema() map[string]any {
+	schema := make(map[string]any)
+	switch s.Type {
+	case ParamTypeInt:
+		schema["type"] = "integer"
+	case ParamTypeFloat:
+		schema["type"] = "number"
+	case ParamTypeBool:
+		schema["type"] = "boolean"
+	case ParamTypeColor:
+		schema["type"] = "string"
+		schema["format"] = "color"
+	case ParamTypeDuration:
+		schema["type"] = "string"
+		schema["format"] = "duration"
+	default:
+		schema["type"] = "string"
+	}
+
+	if s.Type == ParamTypeInt || s.Type == ParamTypeFloat {
+		if s.Min != nil {
+			schema["minimum"] = *s.Min
+		}
+		if s.Max != nil {
+			schema["maximum"] = *s.Max
+		}
+	}
+	if len(s.Enum) > 0 {
+		schema["enum"] = append([]string(nil), s.Enum...)
+	}
+	if s.Unit != "" {
+		schema["x-unit"] = s.Unit
+	}
+	return schema
+}
+
+// typedValue 将字符串值转换为与 Type 对应的 JSON 值，无法转换时原样返回
+func (s ParamSchema) typedValue(value string) any {
+	switch s.Type {
+	case ParamTypeInt:
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case ParamTypeFloat:
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case ParamTypeBool:
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// ParamsJSONSchema 将转换器参数列表导出为 JSON Schema（object 类型）
+//
+// 说明:
+//
+//	可直接 json.Marshal 后用于生成 UI 表单或接口文档。
+func ParamsJSONSchema(params []ConverterParam) map[string]any {
+	var (
+		properties = make(map[string]any, len(params))
+		required   = make([]string, 0)
+	)
+	for _, param := range params {
+		property := param.Schema.JSONSchema()
+		if param.Desc != "" {
+			property["description"] = param.Desc
+		}
+		if param.Default != "" {
+			property["default"] = param.Schema.typedValue(param.Default)
+		}
+		properties[param.Name] = property
+
+		if param.Required {
+			required = append(required, param.Name)
+		}
+	}
+	sort.Strings(required)
+
+	schema := map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -1,6 +1,7 @@
 package contract
 
 // Kind 类型
+// 除内置种类外，也可以自定义种类（例如 Kind("currency")），并通过 RegisterConcept 注册其下的概念
 type Kind string
 
 const (
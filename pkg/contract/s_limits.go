@@ -0,0 +1,90 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// Limits 资源限制，用于防御解压炸弹等恶意输入，字段为 0 表示不限制
+//
+// 说明:
+//
+//	超出限制时返回 exception.ErrLimitExceeded（错误码 exception.CodeLimitExceeded）。
+type Limits struct {
+	// MaxInputBytes 输入数据的最大字节数
+	MaxInputBytes int64
+	// MaxPixels 解码前（根据图片头声明的尺寸）与输出图片的最大像素数（宽 × 高），动画为全部帧的像素数之和
+	MaxPixels int64
+	// MaxOutputWidth 输出图片的最大宽度（像素）
+	MaxOutputWidth int
+	// MaxOutputHeight 输出图片的最大高度（像素）
+	MaxOutputHeight int
+	// MaxSVGElements SVG 的最大元素个数
+	MaxSVGElements int
+}
+
+// DefaultLimits 默认资源限制：输入 256 MiB、1 亿像素、输出 16384 × 16384、SVG 10 万个元素
+func DefaultLimits() Limits {
+	return Limits{
+		MaxInputBytes:   256 << 20,
+		MaxPixels:       100_000_000,
+		MaxOutputWidth:  16384,
+		MaxOutputHeight: 16384,
+		MaxSVGElements:  100_000,
+	}
+}
+
+// LimitsOf 获取 context 中的资源限制，未设置时返回 DefaultLimits
+func LimitsOf(ctx context.Context) Limits {
+	if limits, exist := LimitsFromContext(ctx); exist {
+		return limits
+	}
+	return DefaultLimits()
+}
+
+// CheckInputBytes 校验输入数据的字节数
+func (s Limits) CheckInputBytes(n int64) error {
+	if s.MaxInputBytes > 0 && n > s.MaxInputBytes {
+		return exception.Wrapf(exception.ErrLimitExceeded, "input size %d bytes exceeds max %d bytes", n, s.MaxInputBytes)
+	}
+	return nil
+}
+
+// CheckPixels 校验图片像素数（解码前根据图片头声明的尺寸校验）
+func (s Limits) CheckPixels(width, height int) error {
+	if s.MaxPixels > 0 && int64(width)*int64(height) > s.MaxPixels {
+		return exception.Wrapf(exception.ErrLimitExceeded, "image size %dx%d exceeds max %d pixels", width, height, s.MaxPixels)
+	}
+	return nil
+}
+
+// CheckFramePixels 校验多帧图片（动画）解码后全部帧的像素数之和
+func (s Limits) CheckFramePixels(frames int, pixels int64) error {
+	if s.MaxPixels > 0 && pixels > s.MaxPixels {
+		return exception.Wrapf(
+			exception.ErrLimitExceeded,
+			"%d frames with %d pixels in total exceed max %d pixels", frames, pixels, s.MaxPixels,
+		)
+	}
+	return nil
+}
+
+// CheckOutputSize 校验输出图片的尺寸与像素数
+func (s Limits) CheckOutputSize(width, height int) error {
+	if (s.MaxOutputWidth > 0 && width > s.MaxOutputWidth) || (s.MaxOutputHeight > 0 && height > s.MaxOutputHeight) {
+		return exception.Wrapf(
+			exception.ErrLimitExceeded,
+			"output size %dx%d exceeds max %dx%d", width, height, s.MaxOutputWidth, s.MaxOutputHeight,
+		)
+	}
+	return s.CheckPixels(width, height)
+}
+
+// CheckSVGElements 校验 SVG 元素个数
+func (s Limits) CheckSVGElements(n int) error {
+	if s.MaxSVGElements > 0 && n > s.MaxSVGElements {
+		return exception.Wrapf(exception.ErrLimitExceeded, "svg element count exceeds max %d", s.MaxSVGElements)
+	}
+	return nil
+}
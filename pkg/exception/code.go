@@ -0,0 +1,115 @@
+package exception
+
+import (
+	"context"
+	"net/http"
+)
+
+// Code 稳定的错误码，用于 API 层将错误映射为 HTTP 状态码、用户可读信息等，不随错误信息文本变化
+type Code string
+
+const (
+	CodeUnknown             Code = "UNKNOWN"              // 未知错误
+	CodeInternal            Code = "INTERNAL"             // 内部错误
+	CodeNoConverter         Code = "NO_CONVERTER"         // 不支持的转换
+	CodeIllegalParam        Code = "ILLEGAL_PARAM"        // 非法的转换器参数
+	CodeUnrecognizedContent Code = "UNRECOGNIZED_CONTENT" // 无法识别输入内容的格式
+	CodeDecodeFailed        Code = "DECODE_FAILED"        // 输入数据解码失败
+	CodeEncodeFailed        Code = "ENCODE_FAILED"        // 输出数据编码失败
+	CodeConvertFailed       Code = "CONVERT_FAILED"       // 其他转换失败
+	CodeLimitExceeded       Code = "LIMIT_EXCEEDED"       // 输入超出资源限制
+	CodeCanceled            Code = "CANCELED"             // 调用方取消
+	CodeDeadlineExceeded    Code = "DEADLINE_EXCEEDED"    // 超时
+	CodeConverterConflict   Code = "CONVERTER_CONFLICT"   // 转换器冲突
+	CodeConceptConflict     Code = "CONCEPT_CONFLICT"     // 概念冲突
+)
+
+// StatusClientClosedRequest 客户端主动关闭请求（非标准状态码，沿用 nginx 约定）
+const StatusClientClosedRequest = 499
+
+// codeSpec 错误码规格
+type codeSpec struct {
+	sentinel   error  // 对应的哨兵错误，用于 Is 匹配
+	httpStatus int    // 对应的 HTTP 状态码
+	message    string // 面向用户的默认信息
+}
+
+// _codeSpecs 错误码规格表
+var _codeSpecs = map[Code]codeSpec{
+	CodeUnknown:             {nil, http.StatusInternalServerError, "unknown error"},
+	CodeInternal:            {ErrInternal, http.StatusInternalServerError, "internal error"},
+	CodeNoConverter:         {ErrNoSupportedConverter, http.StatusUnsupportedMediaType, "conversion is not supported"},
+	CodeIllegalParam:        {ErrIllegalConverterParam, http.StatusBadRequest, "invalid conversion parameter"},
+	CodeUnrecognizedContent: {ErrUnrecognizedContent, http.StatusUnsupportedMediaType, "input format could not be recognized"},
+	CodeDecodeFailed:        {ErrDecodeFailed, http.StatusUnprocessableEntity, "input data could not be decoded"},
+	CodeEncodeFailed:        {ErrEncodeFailed, http.StatusInternalServerError, "output data could not be encoded"},
+	CodeConvertFailed:       {ErrConvertFailed, http.StatusInternalServerError, "conversion failed"},
+	CodeLimitExceeded:       {ErrLimitExceeded, http.StatusRequestEntityTooLarge, "input exceeds the allowed limits"},
+	CodeCanceled:            {context.Canceled, StatusClientClosedRequest, "request was canceled"},
+	CodeDeadlineExceeded:    {context.DeadlineExceeded, http.StatusGatewayTimeout, "request timed out"},
+	CodeConverterConflict:   {ErrConverterConflict, http.StatusConflict, "converter conflict"},
+	CodeConceptConflict:     {ErrConceptConflict, http.StatusConflict, "concept conflict"},
+}
+
+// _codePriority 由错误推断错误码时的匹配顺序：越具体、越贴近根因的越靠前
+var _codePriority = []Code{
+	CodeCanceled,
+	CodeDeadlineExceeded,
+	CodeIllegalParam,
+	CodeLimitExceeded,
+	CodeNoConverter,
+	CodeUnrecognizedContent,
+	CodeDecodeFailed,
+	CodeEncodeFailed,
+	CodeConverterConflict,
+	CodeConceptConflict,
+	CodeInternal,
+	CodeConvertFailed,
+}
+
+// HTTPStatus 错误码对应的 HTTP 状态码，未知错误码返回 500
+func (c Code) HTTPStatus() int {
+	if spec, ok := _codeSpecs[c]; ok {
+		return spec.httpStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// Message 错误码对应的面向用户的默认信息（不含内部细节，可直接返回给终端用户）
+func (c Code) Message() string {
+	if spec, ok := _codeSpecs[c]; ok {
+		return spec.message
+	}
+	return _codeSpecs[CodeUnknown].message
+}
+
+// sentinel 错误码对应的哨兵错误，可能为 nil
+func (c Code) sentinel() error {
+	return _codeSpecs[c].sentinel
+}
+
+// CodeOf 获取错误的错误码
+//
+// 说明:
+//
+//	错误树中存在 *RuyiError 时返回其 Code；否则根据哨兵错误推断；err 为 nil 时返回空字符串。
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var ruyiErr *RuyiError
+	if As(err, &ruyiErr) {
+		return ruyiErr.Code
+	}
+	return inferCode(err)
+}
+
+// inferCode 根据哨兵错误推断错误码
+func inferCode(err error) Code {
+	for _, code := range _codePriority {
+		if Is(err, code.sentinel()) {
+			return code
+		}
+	}
+	return CodeUnknown
+}
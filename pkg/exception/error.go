@@ -61,6 +61,11 @@ func (e *joinError) Error() string {
 	return string(b)
 }
 
+// Unwrap 返回合并前的错误列表，使 Is/As 能够遍历合并后的错误树
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
 // Is 判断 err 树中的任意 error 是否与 target 匹配。
 // @param err 原始错误对象
 // @param target 目标错误对象
@@ -1,5 +1,10 @@
 package exception
 
+import (
+	"fmt"
+	"strings"
+)
+
 var (
 	ErrRuyiExpandFailed  = Errorf("ruyi expand failed")
 	ErrRuyiIsBigEnough   = Wrapf(ErrRuyiExpandFailed, "ruyi is big enough")
@@ -12,4 +17,126 @@ var (
 	ErrNoSupportedConverter  = Errorf("no supported converter")
 	ErrConvertFailed         = Errorf("convert failed")
 	ErrIllegalConverterParam = Errorf("illegal converter param") // 非法的转换器参数
+	ErrConverterConflict     = Errorf("converter conflict")      // 转换器重复注册、替换或排除的目标不存在
+	ErrUnrecognizedContent   = Errorf("unrecognized content")    // 无法根据内容识别源 Concept
+	ErrConceptConflict       = Errorf("concept conflict")        // 概念的 name、alias、MIME 类型或扩展名冲突
+)
+
+var (
+	ErrDecodeFailed  = Wrapf(ErrConvertFailed, "decode failed") // 输入数据解码失败
+	ErrEncodeFailed  = Wrapf(ErrConvertFailed, "encode failed") // 输出数据编码失败
+	ErrLimitExceeded = Errorf("limit exceeded")                 // 输入超出资源限制（尺寸、像素数、帧数等）
 )
+
+// RuyiError 结构化的错误，携带稳定的错误码与转换上下文，便于 API 层按 Code 映射 HTTP 状态码与用户信息
+//
+// 说明:
+//
+//	1、exception.Is 同时匹配 Code 对应的哨兵错误（如 ErrIllegalConverterParam）与 Cause 中的任意错误；
+//	2、exception.As 可取出 *RuyiError；也可使用 AsRuyiError 将任意错误转换为 *RuyiError。
+type RuyiError struct {
+	Code    Code   `json:"code"`            // 错误码
+	Message string `json:"message"`         // 错误信息，为空时使用 Code.Message()
+	Kind    string `json:"kind,omitempty"`  // 转换种类
+	From    string `json:"from,omitempty"`  // 源 Concept 名称
+	To      string `json:"to,omitempty"`    // 目标 Concept 名称
+	Param   string `json:"param,omitempty"` // 出错的参数名
+	Cause   error  `json:"-"`               // 底层错误
+}
+
+// NewRuyiError 创建结构化错误
+// @param code 错误码
+// @param cause 底层错误，可为 nil
+// @return *RuyiError 结构化错误
+func NewRuyiError(code Code, cause error) *RuyiError {
+	return &RuyiError{
+		Code:  code,
+		Cause: cause,
+	}
+}
+
+// AsRuyiError 将任意错误转换为结构化错误
+//
+// 说明:
+//
+//	错误树中存在 *RuyiError 时直接返回；否则根据哨兵错误推断错误码，并以 err 作为 Cause 构造；err 为 nil 时返回 nil。
+func AsRuyiError(err error) *RuyiError {
+	if err == nil {
+		return nil
+	}
+	var ruyiErr *RuyiError
+	if As(err, &ruyiErr) {
+		return ruyiErr
+	}
+	return NewRuyiError(inferCode(err), err)
+}
+
+// WithConversion 设置转换上下文，返回自身便于链式调用
+func (e *RuyiError) WithConversion(kind, from, to string) *RuyiError {
+	e.Kind, e.From, e.To = kind, from, to
+	return e
+}
+
+// WithParam 设置出错的参数名，返回自身便于链式调用
+func (e *RuyiError) WithParam(param string) *RuyiError {
+	e.Param = param
+	return e
+}
+
+// WithMessage 设置错误信息，返回自身便于链式调用
+func (e *RuyiError) WithMessage(format string, msgArgs ...any) *RuyiError {
+	e.Message = fmt.Sprintf(format, msgArgs...)
+	return e
+}
+
+// Error 格式：[CODE] message (kind=..., from=..., to=..., param=...): cause
+func (e *RuyiError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(string(e.Code))
+	sb.WriteString("] ")
+	sb.WriteString(e.UserMessage())
+
+	var details []string
+	for _, kv := range [][2]string{{"kind", e.Kind}, {"from", e.From}, {"to", e.To}, {"param", e.Param}} {
+		if kv[1] != "" {
+			details = append(details, kv[0]+"="+kv[1])
+		}
+	}
+	if len(details) > 0 {
+		sb.WriteString(" (")
+		sb.WriteString(strings.Join(details, ", "))
+		sb.WriteString(")")
+	}
+
+	if e.Cause != nil {
+		sb.WriteString(": ")
+		sb.WriteString(e.Cause.Error())
+	}
+	return sb.String()
+}
+
+// UserMessage 面向用户的错误信息，不含底层错误细节
+func (e *RuyiError) UserMessage() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Code.Message()
+}
+
+// HTTPStatus 错误对应的 HTTP 状态码
+func (e *RuyiError) HTTPStatus() int {
+	return e.Code.HTTPStatus()
+}
+
+// Unwrap 返回 Code 对应的哨兵错误与 Cause
+func (e *RuyiError) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if sentinel := e.Code.sentinel(); sentinel != nil {
+		errs = append(errs, sentinel)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
@@ -6,6 +6,8 @@ import (
 )
 
 // New returns a new Ruyi.
-func New() (contract.Ruyi, error) {
-	return internal.New()
+//
+// opts 用于定制转换器集合，例如 WithConverters、WithoutConverters、WithOverride。
+func New(opts ...Option) (contract.Ruyi, error) {
+	return internal.New(opts...)
 }
@@ -0,0 +1,202 @@
+package ruyi
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+var (
+	animRed   = color.NRGBA{R: 0xFF, A: 0xFF}
+	animGreen = color.NRGBA{G: 0xFF, A: 0xFF}
+	animBlue  = color.NRGBA{B: 0xFF, A: 0xFF}
+)
+
+// animatedGIF 生成 40x20 的 3 帧 GIF 动画：红色全画面、左半边绿色的局部帧、蓝色全画面
+func animatedGIF(t *testing.T) []byte {
+	pal := color.Palette{animRed, animGreen, animBlue}
+	frame := func(r image.Rectangle, index uint8) *image.Paletted {
+		img := image.NewPaletted(r, pal)
+		for i := range img.Pix {
+			img.Pix[i] = index
+		}
+		return img
+	}
+	g := &gif.GIF{
+		Image:     []*image.Paletted{frame(image.Rect(0, 0, 40, 20), 0), frame(image.Rect(0, 0, 20, 20), 1), frame(image.Rect(0, 0, 40, 20), 2)},
+		Delay:     []int{10, 20, 30},
+		Disposal:  []byte{gif.DisposalNone, gif.DisposalNone, gif.DisposalNone},
+		LoopCount: 3,
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, g))
+	return buf.Bytes()
+}
+
+func TestAnimatedGIF(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	gifData := animatedGIF(t)
+
+	convertPNG := func(t *testing.T, params map[string]string) image.Image {
+		out, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Png, gifData, params)
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		return img
+	}
+
+	t.Run("frame", func(t *testing.T) {
+		img := convertPNG(t, nil)
+		assertColor(t, animRed, img.At(30, 10))
+
+		// 局部帧合成在上一帧之上
+		img = convertPNG(t, map[string]string{"frame": "1"})
+		assertColor(t, animGreen, img.At(10, 10))
+		assertColor(t, animRed, img.At(30, 10))
+
+		img = convertPNG(t, map[string]string{"frame": "2", "frame_mode": "single"})
+		assertColor(t, animBlue, img.At(10, 10))
+
+		out, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Jpeg, gifData, map[string]string{"frame": "2"})
+		require.NoError(t, err)
+		jpg, err := jpeg.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assertColor(t, animBlue, jpg.At(10, 10))
+
+		_, err = ry.Convert(ctx, contract.File, contract.Gif, contract.Png, gifData, map[string]string{"frame": "3"})
+		require.True(t, exception.Is(err, exception.ErrIllegalConverterParam))
+		paramErrs := contract.ParamErrors(err)
+		require.NotEmpty(t, paramErrs)
+		assert.Equal(t, "frame", paramErrs[0].Name)
+	})
+
+	t.Run("gif to gif 缩放全部帧", func(t *testing.T) {
+		out, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Gif, gifData, map[string]string{"width": "20"})
+		require.NoError(t, err)
+		g, err := gif.DecodeAll(bytes.NewReader(out))
+		require.NoError(t, err)
+		require.Len(t, g.Image, 3)
+		assert.Equal(t, []int{10, 20, 30}, g.Delay)
+		assert.Equal(t, 3, g.LoopCount)
+		assert.Equal(t, 20, g.Config.Width)
+		assert.Equal(t, 10, g.Config.Height)
+		for _, frame := range g.Image {
+			assert.Equal(t, image.Rect(0, 0, 20, 10), frame.Bounds())
+		}
+		assertColor(t, animGreen, g.Image[1].At(2, 5))
+		assertColor(t, animRed, g.Image[1].At(17, 5))
+
+		// single 模式输出静态 GIF
+		out, err = ry.Convert(ctx, contract.File, contract.Gif, contract.Gif, gifData, map[string]string{"frame_mode": "single", "frame": "2"})
+		require.NoError(t, err)
+		g, err = gif.DecodeAll(bytes.NewReader(out))
+		require.NoError(t, err)
+		require.Len(t, g.Image, 1)
+		assertColor(t, animBlue, g.Image[0].At(10, 10))
+	})
+
+	t.Run("sprite", func(t *testing.T) {
+		img := convertPNG(t, map[string]string{"frame_mode": "sprite"})
+		assert.Equal(t, image.Rect(0, 0, 120, 20), img.Bounds())
+		assertColor(t, animRed, img.At(10, 10))
+		assertColor(t, animGreen, img.At(50, 10))
+		assertColor(t, animBlue, img.At(90, 10))
+
+		img = convertPNG(t, map[string]string{"frame_mode": "sprite", "sprite_columns": "2", "width": "20"})
+		assert.Equal(t, image.Rect(0, 0, 40, 20), img.Bounds())
+		assertColor(t, animBlue, img.At(10, 15))
+		_, _, _, a := img.At(30, 15).RGBA()
+		assert.Zero(t, a)
+	})
+
+	t.Run("frames", func(t *testing.T) {
+		// 单张图片目标不再输出 ZIP
+		_, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Png, gifData, map[string]string{"frame_mode": "zip"})
+		require.True(t, exception.Is(err, exception.ErrIllegalConverterParam))
+
+		out, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Frames, gifData, nil)
+		require.NoError(t, err)
+		zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+		require.NoError(t, err)
+		require.Len(t, zr.File, 3)
+		for i, expected := range []color.NRGBA{animRed, animGreen, animBlue} {
+			assert.Equal(t, []string{"frame_000.png", "frame_001.png", "frame_002.png"}[i], zr.File[i].Name)
+			f, err := zr.File[i].Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(f)
+			require.NoError(t, err)
+			img, err := png.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+			assertColor(t, expected, img.At(10, 10))
+		}
+
+		out, err = ry.Convert(ctx, contract.File, contract.Gif, contract.Frames, gifData, map[string]string{"frame_mode": "single", "frame": "1"})
+		require.NoError(t, err)
+		zr, err = zip.NewReader(bytes.NewReader(out), int64(len(out)))
+		require.NoError(t, err)
+		require.Len(t, zr.File, 1)
+	})
+}
+
+func TestAnimatedGIFLimits(t *testing.T) {
+	ctx := context.Background()
+	gifData := animatedGIF(t)
+
+	t.Run("合成之前校验全部帧的像素数", func(t *testing.T) {
+		// 2000x2000 的画布上 60 个 1x1 的帧：文件很小，合成后的全部帧共 2.4 亿像素
+		pal := color.Palette{animRed}
+		g := &gif.GIF{Config: image.Config{ColorModel: pal, Width: 2000, Height: 2000}}
+		for i := 0; i < 60; i++ {
+			g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, 1, 1), pal))
+			g.Delay = append(g.Delay, 10)
+		}
+		var buf bytes.Buffer
+		require.NoError(t, gif.EncodeAll(&buf, g))
+
+		ry, err := ruyi.New(ruyi.WithLimits(contract.Limits{MaxPixels: 10_000_000}))
+		require.NoError(t, err)
+		_, err = ry.Convert(ctx, contract.File, contract.Gif, contract.Gif, buf.Bytes(), nil)
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+
+		// 只输出一帧时只合成到该帧
+		out, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Png, buf.Bytes(), nil)
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 2000, 2000), img.Bounds())
+	})
+
+	t.Run("单帧模式按 frame 之前的帧计算", func(t *testing.T) {
+		// 40x20 的 3 帧：全部帧 2400 像素；第 0 帧需要画布与该帧共 1600 像素，第 2 帧需要 2800 像素
+		ry, err := ruyi.New(ruyi.WithLimits(contract.Limits{MaxPixels: 2000}))
+		require.NoError(t, err)
+
+		_, err = ry.Convert(ctx, contract.File, contract.Gif, contract.Gif, gifData, nil)
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+
+		out, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Png, gifData, nil)
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assertColor(t, animRed, img.At(30, 10))
+
+		_, err = ry.Convert(ctx, contract.File, contract.Gif, contract.Png, gifData, map[string]string{"frame": "2"})
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+	})
+}
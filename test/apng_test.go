@@ -0,0 +1,208 @@
+package ruyi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// appendPNGChunk 追加 PNG 数据块（长度、类型、数据、CRC）
+func appendPNGChunk(out []byte, typ string, data []byte) []byte {
+	out = binary.BigEndian.AppendUint32(out, uint32(len(data)))
+	out = append(out, typ...)
+	out = append(out, data...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(append([]byte(typ), data...)))
+}
+
+// pngIHDRAndIDAT 使用 image/png 编码图片，返回 IHDR 数据与合并后的 IDAT 数据
+func pngIHDRAndIDAT(t *testing.T, img image.Image) (ihdr, idat []byte) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	data := buf.Bytes()[8:]
+	for len(data) >= 12 {
+		size := int(binary.BigEndian.Uint32(data))
+		switch string(data[4:8]) {
+		case "IHDR":
+			ihdr = data[8 : 8+size]
+		case "IDAT":
+			idat = append(idat, data[8:8+size]...)
+		}
+		data = data[12+size:]
+	}
+	return ihdr, idat
+}
+
+// fcTL 生成帧控制块数据
+func fcTL(seq uint32, r image.Rectangle, delayNum, delayDen uint16, dispose, blend byte) []byte {
+	data := binary.BigEndian.AppendUint32(nil, seq)
+	for _, v := range []int{r.Dx(), r.Dy(), r.Min.X, r.Min.Y} {
+		data = binary.BigEndian.AppendUint32(data, uint32(v))
+	}
+	data = binary.BigEndian.AppendUint16(data, delayNum)
+	data = binary.BigEndian.AppendUint16(data, delayDen)
+	return append(data, dispose, blend)
+}
+
+func TestAPNG(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	gifData := animatedGIF(t)
+
+	t.Run("gif 与 apng 互转", func(t *testing.T) {
+		apngData, err := ry.Convert(ctx, contract.File, contract.Gif, contract.Apng, gifData, nil)
+		require.NoError(t, err)
+		concept, exist := contract.DetectConcept(apngData)
+		require.True(t, exist)
+		assert.Equal(t, contract.APNG(), concept)
+
+		// 默认图像为第一帧
+		img, err := png.Decode(bytes.NewReader(apngData))
+		require.NoError(t, err)
+		assertColor(t, animRed, img.At(30, 10))
+
+		out, err := ry.Convert(ctx, contract.File, contract.Apng, contract.Gif, apngData, nil)
+		require.NoError(t, err)
+		g, err := gif.DecodeAll(bytes.NewReader(out))
+		require.NoError(t, err)
+		require.Len(t, g.Image, 3)
+		assert.Equal(t, []int{10, 20, 30}, g.Delay)
+		assert.Equal(t, 3, g.LoopCount)
+		assertColor(t, animGreen, g.Image[1].At(10, 10))
+		assertColor(t, animRed, g.Image[1].At(30, 10))
+		assertColor(t, animBlue, g.Image[2].At(30, 10))
+
+		// 同格式缩放保留全部帧
+		out, err = ry.Convert(ctx, contract.File, contract.Apng, contract.Apng, apngData, map[string]string{"width": "20"})
+		require.NoError(t, err)
+		out, err = ry.Convert(ctx, contract.File, contract.Apng, contract.Png, out, map[string]string{"frame_mode": "sprite"})
+		require.NoError(t, err)
+		img, err = png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 60, 10), img.Bounds())
+		assertColor(t, animGreen, img.At(22, 5))
+		assertColor(t, animBlue, img.At(50, 5))
+	})
+
+	t.Run("帧偏移与混合", func(t *testing.T) {
+		// 4x4 画布：第一帧红色（左上角透明），第二帧为 (2,2) 处 2x2 的蓝色局部帧（左上角透明），按透明度叠加
+		red := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+		blue := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+		for i := range red.Pix {
+			red.Pix[i] = []byte{0xFF, 0, 0, 0xFF}[i%4]
+		}
+		for i := range blue.Pix {
+			blue.Pix[i] = []byte{0, 0, 0xFF, 0xFF}[i%4]
+		}
+		red.SetNRGBA(0, 0, color.NRGBA{})
+		blue.SetNRGBA(0, 0, color.NRGBA{})
+		ihdr, idat := pngIHDRAndIDAT(t, red)
+		_, fdat := pngIHDRAndIDAT(t, blue)
+
+		data := []byte("\x89PNG\r\n\x1a\n")
+		data = appendPNGChunk(data, "IHDR", ihdr)
+		data = appendPNGChunk(data, "acTL", []byte{0, 0, 0, 2, 0, 0, 0, 1})
+		data = appendPNGChunk(data, "fcTL", fcTL(0, red.Bounds(), 1, 10, 0, 0))
+		data = appendPNGChunk(data, "IDAT", idat)
+		data = appendPNGChunk(data, "fcTL", fcTL(1, image.Rect(2, 2, 4, 4), 0, 0, 0, 1))
+		data = appendPNGChunk(data, "fdAT", append([]byte{0, 0, 0, 2}, fdat...))
+		data = appendPNGChunk(data, "IEND", nil)
+
+		concept, exist := contract.DetectConcept(data)
+		require.True(t, exist)
+		assert.Equal(t, contract.APNG(), concept)
+
+		out, err := ry.Convert(ctx, contract.File, contract.Apng, contract.Png, data, map[string]string{"frame": "1"})
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(2, 2)) // 透明像素叠加后保留底下的红色
+		assertColor(t, color.NRGBA{B: 0xFF, A: 0xFF}, img.At(3, 3))
+		_, _, _, a := img.At(0, 0).RGBA()
+		assert.Zero(t, a)
+
+		out, err = ry.Convert(ctx, contract.File, contract.Apng, contract.Gif, data, nil)
+		require.NoError(t, err)
+		g, err := gif.DecodeAll(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, []int{10, 0}, g.Delay)
+		assert.Equal(t, -1, g.LoopCount) // 只播放一次
+	})
+
+	t.Run("静态 png", func(t *testing.T) {
+		_, pngData := gradientPNG(t)
+		concept, exist := contract.DetectConcept(pngData)
+		require.True(t, exist)
+		assert.Equal(t, contract.PNG(), concept)
+
+		out, err := ry.Convert(ctx, contract.File, contract.Apng, contract.Png, pngData, map[string]string{"width": "128"})
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 128, 32), img.Bounds())
+	})
+}
+
+func TestAPNGLimits(t *testing.T) {
+	ctx := context.Background()
+
+	// 2000x2000 的红色画布上 59 个 1x1 的蓝色局部帧，依次位于 (i, 0)，绘制后恢复为上一画面：文件很小，合成后的全部帧共 2.4 亿像素
+	red := image.NewNRGBA(image.Rect(0, 0, 2000, 2000))
+	for i := range red.Pix {
+		red.Pix[i] = []byte{0xFF, 0, 0, 0xFF}[i%4]
+	}
+	ihdr, idat := pngIHDRAndIDAT(t, red)
+	blue := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	blue.SetNRGBA(0, 0, color.NRGBA{B: 0xFF, A: 0xFF})
+	_, fdat := pngIHDRAndIDAT(t, blue)
+	apngData := func(numFrames uint32) []byte {
+		data := []byte("\x89PNG\r\n\x1a\n")
+		data = appendPNGChunk(data, "IHDR", ihdr)
+		data = appendPNGChunk(data, "acTL", binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, numFrames), 0))
+		data = appendPNGChunk(data, "fcTL", fcTL(0, red.Bounds(), 1, 10, 0, 0))
+		data = appendPNGChunk(data, "IDAT", idat)
+		seq := uint32(1)
+		for i := 1; i < 60; i++ {
+			data = appendPNGChunk(data, "fcTL", fcTL(seq, image.Rect(i, 0, i+1, 1), 1, 10, 2, 0))
+			data = appendPNGChunk(data, "fdAT", append(binary.BigEndian.AppendUint32(nil, seq+1), fdat...))
+			seq += 2
+		}
+		return appendPNGChunk(data, "IEND", nil)
+	}
+
+	ry, err := ruyi.New(ruyi.WithLimits(contract.Limits{MaxPixels: 10_000_000}))
+	require.NoError(t, err)
+
+	t.Run("合成之前校验全部帧的像素数", func(t *testing.T) {
+		_, err := ry.Convert(ctx, contract.File, contract.Apng, contract.Gif, apngData(60), nil)
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+	})
+
+	t.Run("单帧模式只合成到 frame 指定的帧", func(t *testing.T) {
+		out, err := ry.Convert(ctx, contract.File, contract.Apng, contract.Png, apngData(60), map[string]string{"frame": "59"})
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assertColor(t, color.NRGBA{B: 0xFF, A: 0xFF}, img.At(59, 0))
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(58, 0)) // 上一帧绘制后已恢复
+	})
+
+	t.Run("acTL 帧数与 fcTL 不一致", func(t *testing.T) {
+		_, err := ry.Convert(ctx, contract.File, contract.Apng, contract.Png, apngData(3), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "acTL declares 3 frames")
+	})
+}
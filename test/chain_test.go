@@ -0,0 +1,75 @@
+package ruyi
+
+import (
+	"bytes"
+	"context"
+	"image/gif"
+	"os"
+	"testing"
+
+	"github.com/biessek/golang-ico"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+func TestChainConverter(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("BMP to GIF 经由 PNG", func(t *testing.T) {
+		conv, err := ry.GetConverter(ctx, contract.File, contract.Bmp, contract.Gif)
+		require.NoError(t, err)
+
+		chain, ok := conv.(contract.ChainConverter)
+		require.True(t, ok)
+
+		var path []contract.ConceptName
+		for _, hop := range chain.Path() {
+			path = append(path, hop.To().Name())
+		}
+		assert.Equal(t, []contract.ConceptName{contract.Png, contract.Gif}, path)
+		assert.Equal(t, contract.BMP(), chain.From())
+		assert.Equal(t, contract.GIF(), chain.To())
+
+		fromData, err := os.ReadFile("testdata/shop.bmp")
+		require.NoError(t, err)
+
+		// 不带前缀的参数交给最后一个声明者（GIF 这一跳）
+		out, err := conv.Convert(ctx, fromData, map[string]string{"width": "32"})
+		require.NoError(t, err)
+		img, err := gif.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 32, img.Bounds().Dx())
+
+		// 带前缀的参数只交给对应的那一跳
+		out, err = conv.Convert(ctx, fromData, map[string]string{"png.width": "16"})
+		require.NoError(t, err)
+		img, err = gif.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 16, img.Bounds().Dx())
+	})
+
+	t.Run("WEBP to ICO", func(t *testing.T) {
+		require.True(t, ry.CanConvert(ctx, contract.File, contract.Webp, contract.Ico))
+
+		fromData, err := os.ReadFile("testdata/shop.webp")
+		require.NoError(t, err)
+
+		out, err := ry.Convert(ctx, contract.File, contract.Webp, contract.Ico, fromData, map[string]string{"width": "48", "height": "48"})
+		require.NoError(t, err)
+		img, err := ico.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 48, img.Bounds().Dx())
+	})
+
+	t.Run("直接转换器优先", func(t *testing.T) {
+		conv, err := ry.GetConverter(ctx, contract.File, contract.Png, contract.Jpeg)
+		require.NoError(t, err)
+		_, ok := conv.(contract.ChainConverter)
+		assert.False(t, ok)
+	})
+}
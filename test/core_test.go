@@ -1,10 +1,12 @@
 package ruyi
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
 )
 
 // Test_NormalizeConcept test core.NormalizeConcept
@@ -20,3 +22,160 @@ func Test_NormalizeConcept(t *testing.T) {
 		require.Equal(t, contract.JPEG(), concept)
 	})
 }
+
+// Test_DetectConcept test contract.DetectConcept
+func Test_DetectConcept(t *testing.T) {
+	files := map[string]contract.Concept{
+		"testdata/shop.png":  contract.PNG(),
+		"testdata/shop.jpg":  contract.JPEG(),
+		"testdata/shop.gif":  contract.GIF(),
+		"testdata/shop.bmp":  contract.BMP(),
+		"testdata/shop.tiff": contract.TIFF(),
+		"testdata/shop.webp": contract.WEBP(),
+		"testdata/shop.ico":  contract.ICO(),
+		"testdata/shop.svg":  contract.SVG(),
+		"testdata/shop.heif": contract.PNG(), // 扩展名为 heif，内容实际是 PNG
+	}
+	for file, expected := range files {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			concept, exist := contract.DetectConcept(data)
+			require.True(t, exist)
+			require.Equal(t, expected, concept)
+		})
+	}
+
+	t.Run("heic", func(t *testing.T) {
+		data := []byte("\x00\x00\x00\x18ftypmif1\x00\x00\x00\x00mif1heic")
+		concept, exist := contract.DetectConcept(data)
+		require.True(t, exist)
+		require.Equal(t, contract.HEIC(), concept)
+
+		// AVIF 同样基于 mif1，但不属于 HEIC
+		_, exist = contract.DetectConcept([]byte("\x00\x00\x00\x18ftypavif\x00\x00\x00\x00mif1avif"))
+		require.False(t, exist)
+	})
+
+	t.Run("svg with xml declaration", func(t *testing.T) {
+		concept, exist := contract.DetectConcept([]byte("\xEF\xBB\xBF\n<?xml version=\"1.0\"?>\n<svg xmlns=\"http://www.w3.org/2000/svg\"/>"))
+		require.True(t, exist)
+		require.Equal(t, contract.SVG(), concept)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, exist := contract.DetectConcept([]byte("hello world"))
+		require.False(t, exist)
+		_, exist = contract.DetectConcept(nil)
+		require.False(t, exist)
+	})
+}
+
+// Test_ConceptMeta test contract.ConceptByMIME / contract.ConceptByExtension
+func Test_ConceptMeta(t *testing.T) {
+	t.Run("meta", func(t *testing.T) {
+		require.Equal(t, "image/svg+xml", contract.SVG().MIMEType())
+		require.Equal(t, ".jpg", contract.JPEG().Extension())
+		require.Equal(t, []string{".heic", ".heif"}, contract.HEIC().Extensions())
+	})
+
+	t.Run("by mime", func(t *testing.T) {
+		cases := map[string]contract.Concept{
+			"image/png":                    contract.PNG(),
+			"IMAGE/JPEG":                   contract.JPEG(),
+			"image/svg+xml; charset=utf-8": contract.SVG(),
+			"image/heif":                   contract.HEIC(),
+			"image/x-icon":                 contract.ICO(),
+		}
+		for mimeType, expected := range cases {
+			concept, exist := contract.ConceptByMIME(mimeType)
+			require.True(t, exist, mimeType)
+			require.Equal(t, expected, concept)
+		}
+
+		_, exist := contract.ConceptByMIME("application/json")
+		require.False(t, exist)
+		_, exist = contract.ConceptByMIME("")
+		require.False(t, exist)
+		// 图标包不占用通用的 ZIP 类型
+		_, exist = contract.ConceptByMIME("application/zip")
+		require.False(t, exist)
+	})
+
+	t.Run("by extension", func(t *testing.T) {
+		cases := map[string]contract.Concept{
+			"png":               contract.PNG(),
+			".JPEG":             contract.JPEG(),
+			"photo.jpg":         contract.JPEG(),
+			"/tmp/scan.TIF":     contract.TIFF(),
+			"IMG_0001.HEIC":     contract.HEIC(),
+			"favicon.ico":       contract.ICO(),
+			"uploads/logo.svg":  contract.SVG(),
+			"archive.tar.webp":  contract.WEBP(),
+			"windows-image.dib": contract.BMP(),
+		}
+		for ext, expected := range cases {
+			concept, exist := contract.ConceptByExtension(ext)
+			require.True(t, exist, ext)
+			require.Equal(t, expected, concept)
+		}
+
+		_, exist := contract.ConceptByExtension("readme.txt")
+		require.False(t, exist)
+		_, exist = contract.ConceptByExtension("")
+		require.False(t, exist)
+		_, exist = contract.ConceptByExtension("icons.zip")
+		require.False(t, exist)
+	})
+}
+
+// Test_RegisterConcept test contract.RegisterConcept
+func Test_RegisterConcept(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		require.Equal(t, contract.Kind("test"), _qoi.Kind())
+
+		concept, exist := contract.NormalizeConcept("qoif")
+		require.True(t, exist)
+		require.Equal(t, _qoi, concept)
+
+		concept, exist = contract.ConceptByExtension("image.qoi")
+		require.True(t, exist)
+		require.Equal(t, _qoi, concept)
+
+		require.Contains(t, contract.Kinds(), contract.Kind("test"))
+		require.Equal(t, []contract.Concept{_qoi}, contract.ConceptsByKind("test"))
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := contract.RegisterConcept("qoi", "test")
+		require.ErrorIs(t, err, exception.ErrConceptConflict)
+
+		// 别名与内置概念冲突
+		_, err = contract.RegisterConcept("jpeg2", contract.File, contract.Jpg)
+		require.ErrorIs(t, err, exception.ErrConceptConflict)
+		_, exist := contract.NormalizeConcept("jpeg2")
+		require.False(t, exist)
+
+		// 扩展名与内置概念冲突
+		_, err = contract.RegisterConceptWithMeta("png2", contract.File, contract.ConceptMeta{Extensions: []string{"PNG"}})
+		require.ErrorIs(t, err, exception.ErrConceptConflict)
+
+		_, err = contract.RegisterConcept("", contract.File)
+		require.ErrorIs(t, err, exception.ErrConceptConflict)
+	})
+}
+
+// _qoi 测试用自定义概念，包级变量保证多次运行测试时只注册一次
+var _qoi = func() contract.Concept {
+	concept, err := contract.RegisterConceptWithMeta(
+		"qoi",
+		"test",
+		contract.ConceptMeta{MIMETypes: []string{"image/qoi"}, Extensions: []string{".qoi"}},
+		"qoif",
+	)
+	if err != nil {
+		panic(err)
+	}
+	return concept
+}()
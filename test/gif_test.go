@@ -0,0 +1,125 @@
+package ruyi
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// gradientPNG 生成 256x64 的渐变 PNG，模拟照片中的平滑过渡
+func gradientPNG(t *testing.T) (*image.NRGBA, []byte) {
+	src := image.NewNRGBA(image.Rect(0, 0, 256, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 256; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(255 - x), B: uint8(y * 4), A: 0xFF})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+	return src, buf.Bytes()
+}
+
+// meanError 两张图片逐像素 RGB 差值的平均值
+func meanError(a, b image.Image) float64 {
+	var sum float64
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, _ := a.At(x, y).RGBA()
+			r2, g2, b2, _ := b.At(x, y).RGBA()
+			for _, d := range []int{int(r1>>8) - int(r2>>8), int(g1>>8) - int(g2>>8), int(b1>>8) - int(b2>>8)} {
+				sum += float64(max(d, -d))
+			}
+		}
+	}
+	return sum / float64(bounds.Dx()*bounds.Dy()*3)
+}
+
+func TestGIFEncode(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	src, pngData := gradientPNG(t)
+
+	decode := func(t *testing.T, params map[string]string) *image.Paletted {
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Gif, pngData, params)
+		require.NoError(t, err)
+		img, err := gif.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		paletted, ok := img.(*image.Paletted)
+		require.True(t, ok)
+		return paletted
+	}
+
+	t.Run("num_colors", func(t *testing.T) {
+		for _, quantizer := range []string{"median-cut", "octree"} {
+			for _, n := range []string{"2", "16", "256"} {
+				img := decode(t, map[string]string{"quantizer": quantizer, "num_colors": n})
+				assert.LessOrEqual(t, len(img.Palette), map[string]int{"2": 2, "16": 16, "256": 256}[n], quantizer)
+			}
+		}
+	})
+
+	t.Run("自适应调色板优于 plan9", func(t *testing.T) {
+		plan9 := meanError(src, decode(t, map[string]string{"quantizer": "plan9", "dither": "none"}))
+		for _, quantizer := range []string{"median-cut", "octree"} {
+			adaptive := meanError(src, decode(t, map[string]string{"quantizer": quantizer, "dither": "none"}))
+			assert.Less(t, adaptive, plan9, quantizer)
+		}
+	})
+
+	t.Run("dither", func(t *testing.T) {
+		// 颜色很少时，抖动会以像素交错模拟中间色
+		none := decode(t, map[string]string{"num_colors": "4", "dither": "none"})
+		dithered := decode(t, map[string]string{"num_colors": "4", "dither": "floyd-steinberg"})
+		assert.NotEqual(t, none.Pix, dithered.Pix)
+	})
+
+	t.Run("transparency", func(t *testing.T) {
+		// 100x100，左半边透明，右半边红色
+		img := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+		draw.Draw(img, image.Rect(50, 0, 100, 100), &image.Uniform{C: color.NRGBA{R: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, img))
+
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Gif, buf.Bytes(), nil)
+		require.NoError(t, err)
+		decoded, err := gif.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		_, _, _, a := decoded.At(25, 50).RGBA()
+		assert.Zero(t, a)
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, decoded.At(75, 50))
+
+		out, err = ry.Convert(ctx, contract.File, contract.Png, contract.Gif, buf.Bytes(),
+			map[string]string{"transparency": "false", "background": "#111"})
+		require.NoError(t, err)
+		decoded, err = gif.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assertColor(t, color.NRGBA{R: 0x11, G: 0x11, B: 0x11, A: 0xFF}, decoded.At(25, 50))
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, decoded.At(75, 50))
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		for _, params := range []map[string]string{
+			{"num_colors": "1"},
+			{"num_colors": "257"},
+			{"quantizer": "k-means"},
+			{"dither": "ordered"},
+		} {
+			_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Gif, pngData, params)
+			assert.True(t, exception.Is(err, exception.ErrIllegalConverterParam), params)
+		}
+	})
+}
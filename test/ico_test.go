@@ -0,0 +1,168 @@
+package ruyi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+
+	ico "github.com/biessek/golang-ico"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// icoSizes 解码 ICO 中的全部图像，返回各图像的尺寸
+func icoSizes(t *testing.T, data []byte) []image.Point {
+	images, err := ico.DecodeAll(bytes.NewReader(data))
+	require.NoError(t, err)
+	sizes := make([]image.Point, 0, len(images))
+	for _, img := range images {
+		sizes = append(sizes, img.Bounds().Size())
+	}
+	return sizes
+}
+
+// mixedICO 生成包含两个图像的 ICO：2x2 红色的 32 位 BMP 图像、4x4 蓝色的 PNG 图像
+func mixedICO(t *testing.T) []byte {
+	// BITMAPINFOHEADER：高度包含 AND 掩码，为实际高度的两倍
+	bmp := binary.LittleEndian.AppendUint32(nil, 40)
+	bmp = binary.LittleEndian.AppendUint32(bmp, 2)
+	bmp = binary.LittleEndian.AppendUint32(bmp, 4)
+	bmp = binary.LittleEndian.AppendUint16(bmp, 1)
+	bmp = binary.LittleEndian.AppendUint16(bmp, 32)
+	bmp = append(bmp, make([]byte, 24)...)
+	for i := 0; i < 4; i++ {
+		bmp = append(bmp, 0, 0, 0xFF, 0xFF) // BGRA
+	}
+	bmp = append(bmp, make([]byte, 8)...) // AND 掩码，每行按 4 字节对齐
+
+	blue := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range blue.Pix {
+		blue.Pix[i] = []byte{0, 0, 0xFF, 0xFF}[i%4]
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, blue))
+
+	data := []byte{0, 0, 1, 0, 2, 0}
+	offset := uint32(6 + 16*2)
+	for _, entry := range []struct {
+		size byte
+		data []byte
+	}{{2, bmp}, {4, buf.Bytes()}} {
+		data = append(data, entry.size, entry.size, 0, 0, 1, 0, 32, 0)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(entry.data)))
+		data = binary.LittleEndian.AppendUint32(data, offset)
+		offset += uint32(len(entry.data))
+	}
+	data = append(data, bmp...)
+	return append(data, buf.Bytes()...)
+}
+
+func TestICO(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pngData, err := os.ReadFile("testdata/shop.png")
+	require.NoError(t, err)
+
+	t.Run("sizes", func(t *testing.T) {
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Ico, pngData, map[string]string{"sizes": "256,16, 48,32,16"})
+		require.NoError(t, err)
+		assert.Equal(t, []image.Point{{16, 16}, {32, 32}, {48, 48}, {256, 256}}, icoSizes(t, out))
+
+		for _, from := range []contract.ConceptName{contract.Jpeg, contract.Svg} {
+			in, err := os.ReadFile("testdata/shop." + map[contract.ConceptName]string{contract.Jpeg: "jpg", contract.Svg: "svg"}[from])
+			require.NoError(t, err)
+			out, err = ry.Convert(ctx, contract.File, from, contract.Ico, in, map[string]string{"sizes": "16,64"})
+			require.NoError(t, err, from)
+			assert.Equal(t, []image.Point{{16, 16}, {64, 64}}, icoSizes(t, out), from)
+		}
+
+		// 未指定 sizes 时保持原有行为：一个与图片尺寸相同的图像
+		out, err = ry.Convert(ctx, contract.File, contract.Png, contract.Ico, pngData, map[string]string{"width": "40"})
+		require.NoError(t, err)
+		require.Len(t, icoSizes(t, out), 1)
+		assert.Equal(t, 40, icoSizes(t, out)[0].X)
+	})
+
+	t.Run("非正方形图片居中", func(t *testing.T) {
+		_, gradient := gradientPNG(t) // 256x64
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Ico, gradient, map[string]string{"sizes": "32"})
+		require.NoError(t, err)
+		img, err := ico.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 32, 32), img.Bounds())
+		_, _, _, a := img.At(16, 2).RGBA()
+		assert.Zero(t, a)
+		_, _, _, a = img.At(16, 16).RGBA()
+		assert.Equal(t, uint32(0xFFFF), a)
+	})
+
+	t.Run("选取图像", func(t *testing.T) {
+		icon, err := ry.Convert(ctx, contract.File, contract.Png, contract.Ico, pngData, map[string]string{"sizes": "16,32,48,256"})
+		require.NoError(t, err)
+
+		cases := []struct {
+			params   map[string]string
+			expected int
+		}{
+			{nil, 256}, // 默认选取最大图像
+			{map[string]string{"size": "32"}, 32},
+			{map[string]string{"size": "40"}, 48},
+			{map[string]string{"size": "1000"}, 256},
+			{map[string]string{"index": "0"}, 16},
+			{map[string]string{"index": "2"}, 48},
+			{map[string]string{"index": "1", "size": "256"}, 32}, // index 优先于 size
+		}
+		for _, c := range cases {
+			out, err := ry.Convert(ctx, contract.File, contract.Ico, contract.Png, icon, c.params)
+			require.NoError(t, err, c.params)
+			img, err := png.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, img.Bounds().Dx(), c.params)
+		}
+
+		// ICO -> ICO：选取一个图像后按 sizes 重新生成
+		out, err := ry.Convert(ctx, contract.File, contract.Ico, contract.Ico, icon, map[string]string{"size": "48", "sizes": "16,24"})
+		require.NoError(t, err)
+		assert.Equal(t, []image.Point{{16, 16}, {24, 24}}, icoSizes(t, out))
+
+		_, err = ry.Convert(ctx, contract.File, contract.Ico, contract.Png, icon, map[string]string{"index": "4"})
+		require.True(t, exception.Is(err, exception.ErrIllegalConverterParam))
+		paramErrs := contract.ParamErrors(err)
+		require.NotEmpty(t, paramErrs)
+		assert.Equal(t, "index", paramErrs[0].Name)
+	})
+
+	t.Run("bmp 与 png 混合", func(t *testing.T) {
+		icon := mixedICO(t)
+		out, err := ry.Convert(ctx, contract.File, contract.Ico, contract.Png, icon, nil)
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 4, img.Bounds().Dx())
+		assertColor(t, color.NRGBA{B: 0xFF, A: 0xFF}, img.At(1, 1))
+
+		out, err = ry.Convert(ctx, contract.File, contract.Ico, contract.Png, icon, map[string]string{"size": "2"})
+		require.NoError(t, err)
+		img, err = png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 2, img.Bounds().Dx())
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(1, 1))
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		for _, sizes := range []string{"0", "16,abc", "512", ","} {
+			_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Ico, pngData, map[string]string{"sizes": sizes})
+			assert.True(t, exception.Is(err, exception.ErrIllegalConverterParam), sizes)
+		}
+	})
+}
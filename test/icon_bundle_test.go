@@ -0,0 +1,182 @@
+package ruyi
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// unzip 读取 ZIP 中的全部文件，返回文件名到内容的映射
+func unzip(t *testing.T, data []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+	}
+	return files
+}
+
+func TestIconBundle(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pngData, err := os.ReadFile("testdata/shop.png")
+	require.NoError(t, err)
+
+	t.Run("图标与尺寸", func(t *testing.T) {
+		svgData, err := os.ReadFile("testdata/shop.svg")
+		require.NoError(t, err)
+
+		for from, in := range map[contract.ConceptName][]byte{contract.Png: pngData, contract.Svg: svgData} {
+			out, err := ry.Convert(ctx, contract.File, from, contract.IconBundle, in, nil)
+			require.NoError(t, err, from)
+			files := unzip(t, out)
+
+			assert.Equal(t, []image.Point{{16, 16}, {32, 32}, {48, 48}}, icoSizes(t, files["favicon.ico"]), from)
+			for name, size := range map[string]int{
+				"favicon-16x16.png":          16,
+				"favicon-32x32.png":          32,
+				"apple-touch-icon.png":       180,
+				"android-chrome-192x192.png": 192,
+				"android-chrome-512x512.png": 512,
+				"maskable-icon-192x192.png":  192,
+				"maskable-icon-512x512.png":  512,
+			} {
+				require.Contains(t, files, name, from)
+				img, err := png.Decode(bytes.NewReader(files[name]))
+				require.NoError(t, err, name)
+				assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds(), name)
+			}
+			assert.Contains(t, files, "site.webmanifest", from)
+			assert.Contains(t, files, "icons.html", from)
+		}
+	})
+
+	t.Run("maskable 安全区", func(t *testing.T) {
+		red := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+		for i := range red.Pix {
+			red.Pix[i] = []byte{0xFF, 0, 0, 0xFF}[i%4]
+		}
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, red))
+
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.IconBundle, buf.Bytes(), map[string]string{
+			"background":       "#0000ff",
+			"maskable_padding": "20",
+		})
+		require.NoError(t, err)
+		files := unzip(t, out)
+
+		img, err := png.Decode(bytes.NewReader(files["maskable-icon-192x192.png"]))
+		require.NoError(t, err)
+		assertColor(t, color.NRGBA{B: 0xFF, A: 0xFF}, img.At(2, 2))
+		assertColor(t, color.NRGBA{B: 0xFF, A: 0xFF}, img.At(96, 30)) // 192 * 20% ≈ 38 以内为留白
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(96, 96))
+
+		// 普通图标铺满画布
+		img, err = png.Decode(bytes.NewReader(files["android-chrome-192x192.png"]))
+		require.NoError(t, err)
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(2, 2))
+
+		// 默认留白：正方形图标连同四角都位于半径为边长 40% 的安全区内
+		out, err = ry.Convert(ctx, contract.File, contract.Png, contract.IconBundle, buf.Bytes(), map[string]string{"background": "#0000ff"})
+		require.NoError(t, err)
+		img, err = png.Decode(bytes.NewReader(unzip(t, out)["maskable-icon-192x192.png"]))
+		require.NoError(t, err)
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(96, 96))
+		radius := 192 * 0.4
+		for y := 0; y < 192; y++ {
+			for x := 0; x < 192; x++ {
+				if r, _, _, _ := img.At(x, y).RGBA(); r == 0 {
+					continue
+				}
+				dx, dy := float64(x)+0.5-96, float64(y)+0.5-96
+				require.LessOrEqual(t, dx*dx+dy*dy, radius*radius, "(%d, %d) 位于安全区之外", x, y)
+			}
+		}
+	})
+
+	t.Run("manifest 与 HTML 片段", func(t *testing.T) {
+		_, gradient := gradientPNG(t) // 256x64，四周透明
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.IconBundle, gradient, map[string]string{
+			"app_name":    "Ruyi",
+			"theme_color": "#336699",
+			"background":  "black",
+			"icon_path":   "/static/icons",
+		})
+		require.NoError(t, err)
+		files := unzip(t, out)
+
+		var manifest struct {
+			Name            string `json:"name"`
+			ShortName       string `json:"short_name"`
+			ThemeColor      string `json:"theme_color"`
+			BackgroundColor string `json:"background_color"`
+			Display         string `json:"display"`
+			Icons           []struct {
+				Src     string `json:"src"`
+				Sizes   string `json:"sizes"`
+				Type    string `json:"type"`
+				Purpose string `json:"purpose"`
+			} `json:"icons"`
+		}
+		require.NoError(t, json.Unmarshal(files["site.webmanifest"], &manifest))
+		assert.Equal(t, "Ruyi", manifest.Name)
+		assert.Equal(t, "Ruyi", manifest.ShortName)
+		assert.Equal(t, "#336699", manifest.ThemeColor)
+		assert.Equal(t, "#000000", manifest.BackgroundColor)
+		assert.Equal(t, "standalone", manifest.Display)
+		require.Len(t, manifest.Icons, 4)
+		assert.Equal(t, "/static/icons/android-chrome-192x192.png", manifest.Icons[0].Src)
+		assert.Equal(t, "192x192", manifest.Icons[0].Sizes)
+		assert.Equal(t, "image/png", manifest.Icons[0].Type)
+		assert.Empty(t, manifest.Icons[0].Purpose)
+		assert.Equal(t, "/static/icons/maskable-icon-512x512.png", manifest.Icons[3].Src)
+		assert.Equal(t, "maskable", manifest.Icons[3].Purpose)
+
+		snippet := string(files["icons.html"])
+		assert.Contains(t, snippet, `<link rel="icon" href="/static/icons/favicon.ico" sizes="any">`)
+		assert.Contains(t, snippet, `<link rel="apple-touch-icon" sizes="180x180" href="/static/icons/apple-touch-icon.png">`)
+		assert.Contains(t, snippet, `<link rel="manifest" href="/static/icons/site.webmanifest">`)
+		assert.Contains(t, snippet, `<meta name="theme-color" content="#336699">`)
+
+		// apple-touch-icon 不透明，透明区域铺上背景色；Android 图标保留透明
+		img, err := png.Decode(bytes.NewReader(files["apple-touch-icon.png"]))
+		require.NoError(t, err)
+		assertColor(t, color.NRGBA{A: 0xFF}, img.At(2, 2))
+		img, err = png.Decode(bytes.NewReader(files["android-chrome-192x192.png"]))
+		require.NoError(t, err)
+		_, _, _, a := img.At(2, 2).RGBA()
+		assert.Zero(t, a)
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		for _, params := range []map[string]string{
+			{"maskable_padding": "50"},
+			{"maskable_padding": "abc"},
+			{"theme_color": "nope"},
+		} {
+			_, err := ry.Convert(ctx, contract.File, contract.Png, contract.IconBundle, pngData, params)
+			assert.True(t, exception.Is(err, exception.ErrIllegalConverterParam), params)
+		}
+	})
+}
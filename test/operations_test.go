@@ -0,0 +1,128 @@
+package ruyi
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+func TestOperations(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// 200x100，左半边红色，右半边蓝色
+	src := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(src, image.Rect(0, 0, 100, 100), &image.Uniform{C: color.NRGBA{R: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+	draw.Draw(src, image.Rect(100, 0, 200, 100), &image.Uniform{C: color.NRGBA{B: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+	pngData := buf.Bytes()
+
+	red := color.NRGBA{R: 0xFF, A: 0xFF}
+	blue := color.NRGBA{B: 0xFF, A: 0xFF}
+	white := color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+
+	type pixel struct {
+		at    image.Point
+		color color.NRGBA
+	}
+	cases := []struct {
+		name   string
+		from   contract.ConceptName
+		to     contract.ConceptName
+		in     []byte
+		params map[string]string
+		width  int
+		height int
+		pixels []pixel
+	}{
+		{"crop", contract.Png, contract.Tiff, pngData, map[string]string{"crop": "0,0,100,100"}, 100, 100,
+			[]pixel{{image.Pt(0, 0), red}, {image.Pt(99, 99), red}}},
+		{"crop 超出边界时截取", contract.Png, contract.Tiff, pngData, map[string]string{"crop": "150,50,100,100"}, 50, 50,
+			[]pixel{{image.Pt(0, 0), blue}, {image.Pt(49, 49), blue}}},
+		{"rotate=90 顺时针", contract.Png, contract.Tiff, pngData, map[string]string{"rotate": "90"}, 100, 200,
+			[]pixel{{image.Pt(50, 50), red}, {image.Pt(50, 150), blue}}},
+		{"rotate=-90 逆时针", contract.Png, contract.Tiff, pngData, map[string]string{"rotate": "-90"}, 100, 200,
+			[]pixel{{image.Pt(50, 50), blue}, {image.Pt(50, 150), red}}},
+		{"rotate=45 填充背景", contract.Png, contract.Tiff, pngData, map[string]string{"rotate": "45", "background": "white"}, 212, 212,
+			[]pixel{{image.Pt(0, 0), white}, {image.Pt(211, 211), white}}},
+		{"flip=horizontal", contract.Png, contract.Tiff, pngData, map[string]string{"flip": "horizontal"}, 200, 100,
+			[]pixel{{image.Pt(50, 50), blue}, {image.Pt(150, 50), red}}},
+		{"crop -> rotate -> resize", contract.Png, contract.Tiff, pngData, map[string]string{"crop": "0,0,200,100", "rotate": "90", "width": "50"}, 50, 100,
+			[]pixel{{image.Pt(25, 25), red}, {image.Pt(25, 75), blue}}},
+		{"svg crop", contract.Svg, contract.Png, []byte(resizeTestSVG), map[string]string{"crop": "100,0,100,100", "width": "50"}, 50, 50,
+			[]pixel{{image.Pt(25, 25), blue}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := ry.Convert(ctx, contract.File, c.from, c.to, c.in, c.params)
+			require.NoError(t, err)
+
+			img, _, err := image.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.InDelta(t, c.width, img.Bounds().Dx(), 1)
+			assert.InDelta(t, c.height, img.Bounds().Dy(), 1)
+			for _, p := range c.pixels {
+				assertColor(t, p.color, img.At(p.at.X, p.at.Y))
+			}
+		})
+	}
+
+	t.Run("色彩调整与滤镜", func(t *testing.T) {
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Tiff, pngData, map[string]string{"grayscale": "true"})
+		require.NoError(t, err)
+		img, _, err := image.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		r, g, b, _ := img.At(50, 50).RGBA()
+		assert.Equal(t, r, g)
+		assert.Equal(t, g, b)
+
+		out, err = ry.Convert(ctx, contract.File, contract.Png, contract.Tiff, pngData, map[string]string{"brightness": "50"})
+		require.NoError(t, err)
+		img, _, err = image.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		_, g, _, _ = img.At(50, 50).RGBA()
+		assert.Greater(t, g>>8, uint32(0x60))
+
+		out, err = ry.Convert(ctx, contract.File, contract.Png, contract.Tiff, pngData, map[string]string{"blur": "5"})
+		require.NoError(t, err)
+		img, _, err = image.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		r, _, b, _ = img.At(100, 50).RGBA()
+		assert.Greater(t, r>>8, uint32(0x20))
+		assert.Greater(t, b>>8, uint32(0x20))
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		for _, params := range []map[string]string{
+			{"crop": "0,0,100"},
+			{"crop": "0,0,0,100"},
+			{"crop": "300,0,10,10"}, // 与图片不相交
+			{"rotate": "abc"},
+			{"flip": "diagonal"},
+			{"gamma": "0"},
+		} {
+			_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Tiff, pngData, params)
+			require.Error(t, err, params)
+			assert.True(t, exception.Is(err, exception.ErrIllegalConverterParam), params)
+			paramErrs := contract.ParamErrors(err)
+			require.NotEmpty(t, paramErrs, params)
+			for name := range params {
+				assert.Equal(t, name, paramErrs[0].Name)
+			}
+		}
+	})
+}
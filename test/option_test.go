@@ -0,0 +1,142 @@
+package ruyi
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+// echoConverter 原样返回输入的自定义转换器
+type echoConverter struct {
+	from contract.Concept
+	to   contract.Concept
+}
+
+func (s *echoConverter) From() contract.Concept { return s.from }
+
+func (s *echoConverter) To() contract.Concept { return s.to }
+
+func (s *echoConverter) Params() []contract.ConverterParam { return nil }
+
+func (s *echoConverter) Convert(ctx context.Context, in []byte, params map[string]string) ([]byte, error) {
+	return in, nil
+}
+
+func TestNewWithOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("WithConverters", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithConverters(&echoConverter{from: contract.BMP(), to: contract.GIF()}))
+		require.NoError(t, err)
+
+		out, err := ry.Convert(ctx, contract.File, contract.Bmp, contract.Gif, []byte("echo"), nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("echo"), out)
+	})
+
+	t.Run("WithConverters 自定义概念", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithConverters(&echoConverter{from: _qoi, to: _qoi}))
+		require.NoError(t, err)
+
+		require.Contains(t, ry.ListKinds(), _qoi.Kind())
+		out, err := ry.Convert(ctx, _qoi.Kind(), "qoif", "qoi", []byte("echo"), nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("echo"), out)
+	})
+
+	t.Run("WithConverters 与内置转换器重复", func(t *testing.T) {
+		_, err := ruyi.New(ruyi.WithConverters(&echoConverter{from: contract.PNG(), to: contract.JPEG()}))
+		require.ErrorIs(t, err, exception.ErrConverterConflict)
+	})
+
+	t.Run("WithoutConverters", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithoutConverters(contract.Png, contract.Jpg))
+		require.NoError(t, err)
+		for _, c := range ry.ListConverters(contract.File) {
+			require.False(t, c.From().Name() == contract.Png && c.To().Name() == contract.Jpeg)
+		}
+
+		_, err = ruyi.New(ruyi.WithoutConverters(contract.Png, contract.Heic))
+		require.ErrorIs(t, err, exception.ErrConverterConflict)
+	})
+
+	t.Run("WithOverride", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithOverride(&echoConverter{from: contract.PNG(), to: contract.JPEG()}))
+		require.NoError(t, err)
+
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, []byte("echo"), nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("echo"), out)
+
+		_, err = ruyi.New(ruyi.WithOverride(&echoConverter{from: contract.BMP(), to: contract.GIF()}))
+		require.ErrorIs(t, err, exception.ErrConverterConflict)
+	})
+}
+
+func TestRuntimeRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	t.Run("重复注册", func(t *testing.T) {
+		err := ry.RegisterConverters(&echoConverter{from: contract.PNG(), to: contract.JPEG()})
+		require.ErrorIs(t, err, exception.ErrConverterConflict)
+
+		// 任意一个重复时不注册任何转换器
+		total := len(ry.ListConverters(contract.File))
+		err = ry.RegisterConverters(
+			&echoConverter{from: contract.BMP(), to: contract.GIF()},
+			&echoConverter{from: contract.BMP(), to: contract.GIF()},
+		)
+		require.ErrorIs(t, err, exception.ErrConverterConflict)
+		err = ry.RegisterConverters(
+			&echoConverter{from: contract.BMP(), to: contract.GIF()},
+			&echoConverter{from: contract.PNG(), to: contract.JPEG()},
+		)
+		require.ErrorIs(t, err, exception.ErrConverterConflict)
+		require.Len(t, ry.ListConverters(contract.File), total)
+	})
+
+	t.Run("注册、替换、注销", func(t *testing.T) {
+		total := len(ry.ListConverters(contract.File))
+
+		require.NoError(t, ry.RegisterConverters(&echoConverter{from: contract.BMP(), to: contract.GIF()}))
+		require.Len(t, ry.ListConverters(contract.File), total+1)
+
+		require.NoError(t, ry.ReplaceConverters(&echoConverter{from: contract.PNG(), to: contract.JPEG()}))
+		require.Len(t, ry.ListConverters(contract.File), total+1)
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpg, []byte("echo"), nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("echo"), out)
+
+		require.NoError(t, ry.UnregisterConverter(ctx, contract.File, contract.Bmp, contract.Gif))
+		require.Len(t, ry.ListConverters(contract.File), total)
+
+		err = ry.UnregisterConverter(ctx, contract.File, contract.Bmp, contract.Gif)
+		require.ErrorIs(t, err, exception.ErrNoSupportedConverter)
+	})
+
+	t.Run("并发读写", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = ry.ReplaceConverters(&echoConverter{from: contract.ICO(), to: contract.GIF()})
+				_ = ry.UnregisterConverter(ctx, contract.File, contract.Ico, contract.Gif)
+			}()
+			go func() {
+				defer wg.Done()
+				_ = ry.CanConvert(ctx, contract.File, contract.Bmp, contract.Ico)
+				_ = ry.TargetsFrom(contract.File, contract.Png)
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,96 @@
+package ruyi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// orientedJPEG 生成 200x100（左半边红色，右半边蓝色）的 JPEG，并在 SOI 之后插入携带 Orientation 的 APP1 段
+func orientedJPEG(t *testing.T, orientation uint16, order binary.ByteOrder) []byte {
+	src := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(src, image.Rect(0, 0, 100, 100), &image.Uniform{C: color.NRGBA{R: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+	draw.Draw(src, image.Rect(100, 0, 200, 100), &image.Uniform{C: color.NRGBA{B: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, src, &jpeg.Options{Quality: 100}))
+	data := buf.Bytes()
+
+	// TIFF 头 + IFD0（1 个条目：Orientation, SHORT, 1）
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func TestAutoOrient(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	cases := []struct {
+		name        string
+		orientation uint16
+		order       binary.ByteOrder
+		params      map[string]string
+		width       int
+		height      int
+		red         image.Point // 红色区域内的一点
+	}{
+		{"orientation=1", 1, binary.BigEndian, nil, 200, 100, image.Pt(50, 50)},
+		{"orientation=3", 3, binary.LittleEndian, nil, 200, 100, image.Pt(150, 50)},
+		// 顺时针旋转 90 度：左半边转到上半部分
+		{"orientation=6", 6, binary.BigEndian, nil, 100, 200, image.Pt(50, 50)},
+		// 逆时针旋转 90 度：左半边转到下半部分
+		{"orientation=8", 8, binary.LittleEndian, nil, 100, 200, image.Pt(50, 150)},
+		// 先旋转再缩放
+		{"orientation=6 width=50", 6, binary.BigEndian, map[string]string{"width": "50"}, 50, 100, image.Pt(25, 25)},
+		{"auto_orient=false", 6, binary.BigEndian, map[string]string{"auto_orient": "false"}, 200, 100, image.Pt(50, 50)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			in := orientedJPEG(t, c.orientation, c.order)
+			out, err := ry.Convert(ctx, contract.File, contract.Jpeg, contract.Png, in, c.params)
+			require.NoError(t, err)
+
+			img, err := png.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, c.width, img.Bounds().Dx())
+			assert.Equal(t, c.height, img.Bounds().Dy())
+			r, g, b, _ := img.At(c.red.X, c.red.Y).RGBA()
+			assert.Greater(t, r>>8, uint32(0xE0))
+			assert.Less(t, g>>8, uint32(0x20))
+			assert.Less(t, b>>8, uint32(0x20))
+		})
+	}
+}
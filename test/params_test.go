@@ -0,0 +1,218 @@
+package ruyi
+
+import (
+	"context"
+	"encoding/json"
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
+)
+
+func TestParamSchema(t *testing.T) {
+	t.Run("check", func(t *testing.T) {
+		intSchema := contract.ParamSchema{Type: contract.ParamTypeInt}.WithRange(1, 100)
+		assert.NoError(t, intSchema.Check("1"))
+		assert.NoError(t, intSchema.Check(""))
+		assert.Error(t, intSchema.Check("0"))
+		assert.Error(t, intSchema.Check("1.5"))
+
+		floatSchema := contract.ParamSchema{Type: contract.ParamTypeFloat}.WithMin(0)
+		assert.NoError(t, floatSchema.Check("0.5"))
+		assert.Error(t, floatSchema.Check("-0.5"))
+		assert.Error(t, floatSchema.Check("NaN"))
+
+		boolSchema := contract.ParamSchema{Type: contract.ParamTypeBool}
+		assert.NoError(t, boolSchema.Check("true"))
+		assert.Error(t, boolSchema.Check("yes"))
+
+		enumSchema := contract.ParamSchema{Type: contract.ParamTypeEnum}.WithEnum("fit", "fill")
+		assert.NoError(t, enumSchema.Check("FIT"))
+		assert.Error(t, enumSchema.Check("cover"))
+
+		colorSchema := contract.ParamSchema{Type: contract.ParamTypeColor}
+		assert.NoError(t, colorSchema.Check("#111"))
+		assert.Error(t, colorSchema.Check("#11"))
+
+		durationSchema := contract.ParamSchema{Type: contract.ParamTypeDuration}.WithMax(1)
+		assert.NoError(t, durationSchema.Check("500ms"))
+		assert.Error(t, durationSchema.Check("2s"))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, "int [1, 100]", contract.ParamSchema{Type: contract.ParamTypeInt}.WithRange(1, 100).String())
+		assert.Equal(t, "int [0, +∞) px", contract.ParamSchema{Type: contract.ParamTypeInt}.WithMin(0).WithUnit("px").String())
+		assert.Equal(t, "enum (fit|fill)", contract.ParamSchema{Type: contract.ParamTypeEnum}.WithEnum("fit", "fill").String())
+	})
+
+	t.Run("json schema", func(t *testing.T) {
+		ry, err := ruyi.New()
+		require.NoError(t, err)
+
+		conv, err := ry.GetConverter(context.Background(), contract.File, contract.Png, contract.Jpeg)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(contract.ParamsJSONSchema(conv.Params()))
+		require.NoError(t, err)
+
+		var schema struct {
+			Type       string `json:"type"`
+			Properties map[string]struct {
+				Type    string  `json:"type"`
+				Minimum float64 `json:"minimum"`
+				Maximum float64 `json:"maximum"`
+				Default any     `json:"default"`
+			} `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(data, &schema))
+		assert.Equal(t, "object", schema.Type)
+		assert.Equal(t, "integer", schema.Properties["quality"].Type)
+		assert.Equal(t, float64(1), schema.Properties["quality"].Minimum)
+		assert.Equal(t, float64(100), schema.Properties["quality"].Maximum)
+		assert.Equal(t, float64(100), schema.Properties["quality"].Default)
+	})
+}
+
+func TestParseColor(t *testing.T) {
+	cases := map[string]color.NRGBA{
+		"#111":                  {R: 0x11, G: 0x11, B: 0x11, A: 0xFF},
+		"#11223380":             {R: 0x11, G: 0x22, B: 0x33, A: 0x80},
+		"FFFFFF":                {R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+		"rgb(17, 17, 17)":       {R: 17, G: 17, B: 17, A: 0xFF},
+		"RGBA(255, 0, 0, 0.5)":  {R: 255, A: 0x80},
+		"White":                 {R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+		"transparent":           {},
+		" rgba(0,0,0,1) ":       {A: 0xFF},
+		"#0000":                 {},
+		"rgb( 1 , 2 , 3 )":      {R: 1, G: 2, B: 3, A: 0xFF},
+		"rgba(10, 20, 30, 0.0)": {R: 10, G: 20, B: 30},
+	}
+	for value, expected := range cases {
+		c, err := contract.ParseColor(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, c, value)
+	}
+
+	for _, value := range []string{"", "#12", "#gggggg", "rgb(256, 0, 0)", "rgba(0, 0, 0, 2)", "rgb(0, 0)", "not-a-color"} {
+		_, err := contract.ParseColor(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestStrictParams(t *testing.T) {
+	ctx := context.Background()
+	in, err := os.ReadFile("testdata/shop.png")
+	require.NoError(t, err)
+
+	t.Run("required", func(t *testing.T) {
+		params := contract.NewConverterParams(contract.ConverterParam{Name: "token", Required: true})
+
+		_, err := params.CheckAndGetParams(map[string]string{})
+		require.ErrorIs(t, err, exception.ErrIllegalConverterParam)
+		paramErrs := contract.ParamErrors(err)
+		require.Len(t, paramErrs, 1)
+		assert.Equal(t, contract.ParamMissing, paramErrs[0].Reason)
+		assert.Equal(t, "token", paramErrs[0].Name)
+
+		validParams, err := params.CheckAndGetParams(map[string]string{"token": "abc"})
+		require.NoError(t, err)
+		assert.Equal(t, "abc", validParams["token"])
+
+		// 空字符串等同于未传入
+		_, err = params.CheckAndGetParams(map[string]string{"token": ""})
+		require.ErrorIs(t, err, exception.ErrIllegalConverterParam)
+	})
+
+	t.Run("empty value uses default", func(t *testing.T) {
+		params := contract.NewConverterParams(contract.ConverterParam{
+			Name:    "quality",
+			Default: "100",
+			Schema:  contract.ParamSchema{Type: contract.ParamTypeInt}.WithRange(1, 100),
+			Check: func(value string) error {
+				require.NotEmpty(t, value, "空字符串不应交给 Check 校验")
+				return nil
+			},
+		})
+		validParams, err := params.CheckAndGetParams(map[string]string{"quality": ""})
+		require.NoError(t, err)
+		assert.Equal(t, "100", validParams["quality"])
+
+		ry, err := ruyi.New()
+		require.NoError(t, err)
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, in, map[string]string{"quality": ""})
+		assert.NoError(t, err)
+	})
+
+	t.Run("non-strict ignores unknown params", func(t *testing.T) {
+		ry, err := ruyi.New()
+		require.NoError(t, err)
+
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, in, map[string]string{"qualty": "50"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("strict rejects unknown params", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithStrictParams(true))
+		require.NoError(t, err)
+
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, in, map[string]string{
+			"qualty": "50",
+			"width":  "-1",
+		})
+		require.ErrorIs(t, err, exception.ErrIllegalConverterParam)
+
+		var paramErr *contract.ParamError
+		require.True(t, exception.As(err, &paramErr))
+
+		paramErrs := contract.ParamErrors(err)
+		require.Len(t, paramErrs, 2)
+		reasons := map[string]contract.ParamError{}
+		for _, e := range paramErrs {
+			reasons[e.Name] = *e
+		}
+		assert.Equal(t, contract.ParamUnknown, reasons["qualty"].Reason)
+		assert.Equal(t, []string{"quality"}, reasons["qualty"].Suggestions)
+		assert.Equal(t, contract.ParamInvalid, reasons["width"].Reason)
+		assert.Contains(t, err.Error(), "did you mean quality?")
+
+		// context 覆盖实例配置
+		_, err = ry.Convert(contract.ContextWithStrictParams(ctx, false), contract.File, contract.Png, contract.Jpeg, in, map[string]string{"qualty": "50"})
+		assert.NoError(t, err)
+
+		// GetConverter 取得的转换器同样遵循实例配置，多跳转换器保留 ChainConverter 接口
+		for _, to := range []contract.ConceptName{contract.Jpeg, contract.Tiff} {
+			conv, err := ry.GetConverter(ctx, contract.File, contract.Png, to)
+			require.NoError(t, err)
+			_, err = conv.Convert(ctx, in, map[string]string{"qualty": "50"})
+			require.ErrorIs(t, err, exception.ErrIllegalConverterParam, to)
+		}
+		conv, err := ry.GetConverter(ctx, contract.File, contract.Bmp, contract.Gif)
+		require.NoError(t, err)
+		_, ok := conv.(contract.ChainConverter)
+		assert.True(t, ok)
+	})
+
+	t.Run("strict chain params", func(t *testing.T) {
+		ry, err := ruyi.New()
+		require.NoError(t, err)
+		strictCtx := contract.ContextWithStrictParams(ctx, true)
+
+		// svg -> png -> tiff，png.width 只路由到 svg -> png
+		svg, err := os.ReadFile("testdata/shop.svg")
+		require.NoError(t, err)
+		_, err = ry.Convert(strictCtx, contract.File, contract.Svg, contract.Tiff, svg, map[string]string{"png.width": "64"})
+		require.NoError(t, err)
+
+		_, err = ry.Convert(strictCtx, contract.File, contract.Svg, contract.Tiff, svg, map[string]string{"png.widht": "64"})
+		require.ErrorIs(t, err, exception.ErrIllegalConverterParam)
+		paramErrs := contract.ParamErrors(err)
+		require.Len(t, paramErrs, 1)
+		assert.Equal(t, "widht", paramErrs[0].Name)
+		assert.Equal(t, []string{"width"}, paramErrs[0].Suggestions)
+	})
+}
@@ -0,0 +1,262 @@
+package ruyi
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wukong-app/ruyi"
+	"github.com/wukong-app/ruyi/pkg/contract"
+)
+
+// 200x100，左半边红色，右半边蓝色
+const resizeTestSVG = `<svg width="200" height="100" viewBox="0 0 200 100" xmlns="http://www.w3.org/2000/svg">` +
+	`<rect x="0" y="0" width="100" height="100" fill="#ff0000" />` +
+	`<rect x="100" y="0" width="100" height="100" fill="#0000ff" />` +
+	`</svg>`
+
+func TestResizeMode(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// 200x100，左半边红色，右半边蓝色
+	src := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(src, image.Rect(0, 0, 100, 100), &image.Uniform{C: color.NRGBA{R: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+	draw.Draw(src, image.Rect(100, 0, 200, 100), &image.Uniform{C: color.NRGBA{B: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+	pngData := buf.Bytes()
+	svgData := []byte(resizeTestSVG)
+
+	sources := []struct {
+		from contract.ConceptName
+		to   contract.ConceptName
+		in   []byte
+	}{
+		{contract.Png, contract.Jpeg, pngData},
+		{contract.Png, contract.Gif, pngData},
+		{contract.Svg, contract.Png, svgData},
+		{contract.Svg, contract.Jpeg, svgData},
+	}
+
+	cases := []struct {
+		params map[string]string
+		width  int
+		height int
+	}{
+		{map[string]string{"width": "100", "height": "100"}, 100, 100},
+		{map[string]string{"width": "100", "height": "100", "resize_mode": "exact"}, 100, 100},
+		{map[string]string{"width": "100", "height": "100", "resize_mode": "fit"}, 100, 50},
+		{map[string]string{"width": "100", "height": "100", "resize_mode": "cover"}, 200, 100},
+		{map[string]string{"width": "100", "height": "100", "resize_mode": "fill"}, 100, 100},
+		{map[string]string{"width": "100", "height": "100", "resize_mode": "pad"}, 100, 100},
+		{map[string]string{"width": "50", "resize_mode": "fill"}, 50, 25},
+		{map[string]string{"height": "50", "resize_mode": "pad"}, 100, 50},
+	}
+
+	for _, source := range sources {
+		for _, c := range cases {
+			out, err := ry.Convert(ctx, contract.File, source.from, source.to, source.in, c.params)
+			require.NoError(t, err, "%s -> %s %v", source.from, source.to, c.params)
+
+			config, _, err := image.DecodeConfig(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, c.width, config.Width, "%s -> %s %v", source.from, source.to, c.params)
+			assert.Equal(t, c.height, config.Height, "%s -> %s %v", source.from, source.to, c.params)
+		}
+	}
+
+	decodePNG := func(t *testing.T, params map[string]string) image.Image {
+		out, err := ry.Convert(ctx, contract.File, contract.Svg, contract.Png, svgData, params)
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		return img
+	}
+
+	t.Run("fill gravity", func(t *testing.T) {
+		img := decodePNG(t, map[string]string{"width": "100", "height": "100", "resize_mode": "fill", "gravity": "left"})
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(50, 50))
+
+		img = decodePNG(t, map[string]string{"width": "100", "height": "100", "resize_mode": "fill", "gravity": "right"})
+		assertColor(t, color.NRGBA{B: 0xFF, A: 0xFF}, img.At(50, 50))
+	})
+
+	t.Run("pad background", func(t *testing.T) {
+		img := decodePNG(t, map[string]string{"width": "100", "height": "100", "resize_mode": "pad", "background": "#00ff00"})
+		assertColor(t, color.NRGBA{G: 0xFF, A: 0xFF}, img.At(50, 5))
+		assertColor(t, color.NRGBA{R: 0xFF, A: 0xFF}, img.At(25, 50))
+
+		img = decodePNG(t, map[string]string{"width": "100", "height": "100", "resize_mode": "pad", "gravity": "top"})
+		assertColor(t, color.NRGBA{}, img.At(50, 95))
+	})
+
+	t.Run("png to svg", func(t *testing.T) {
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Svg, pngData, map[string]string{
+			"width": "100", "height": "100", "resize_mode": "fill", "gravity": "top_left",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(out), `width="100" height="100" viewBox="0 0 200 100" preserveAspectRatio="xMinYMin slice"`)
+	})
+
+	t.Run("illegal params", func(t *testing.T) {
+		_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, pngData, map[string]string{"resize_mode": "zoom"})
+		require.Error(t, err)
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, pngData, map[string]string{"background": "#12"})
+		require.Error(t, err)
+	})
+}
+
+// assertColor 比较颜色（允许少量误差）
+func assertColor(t *testing.T, expected color.NRGBA, actual color.Color) {
+	t.Helper()
+	c := color.NRGBAModel.Convert(actual).(color.NRGBA)
+	diff := func(a, b uint8) int {
+		if a > b {
+			return int(a - b)
+		}
+		return int(b - a)
+	}
+	assert.True(t,
+		diff(expected.R, c.R) <= 8 && diff(expected.G, c.G) <= 8 && diff(expected.B, c.B) <= 8 && diff(expected.A, c.A) <= 8,
+		"expected %v, got %v", expected, c,
+	)
+}
+
+func TestResizeFilterAndNoUpscale(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// 2x2 像素画：黑白棋盘
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.NRGBA{A: 0xFF})
+	src.Set(1, 0, color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF})
+	src.Set(0, 1, color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF})
+	src.Set(1, 1, color.NRGBA{A: 0xFF})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+	pngData := buf.Bytes()
+
+	convert := func(t *testing.T, params map[string]string) image.Image {
+		out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Tiff, pngData, params)
+		require.NoError(t, err)
+		img, _, err := image.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		return img
+	}
+
+	t.Run("nearest", func(t *testing.T) {
+		img := convert(t, map[string]string{"width": "8", "height": "8", "filter": "nearest"})
+		for y := 0; y < 8; y++ {
+			for x := 0; x < 8; x++ {
+				expected := src.NRGBAAt(x/4, y/4)
+				assert.Equal(t, expected, color.NRGBAModel.Convert(img.At(x, y)), "(%d, %d)", x, y)
+			}
+		}
+	})
+
+	t.Run("lanczos by default", func(t *testing.T) {
+		img := convert(t, map[string]string{"width": "8", "height": "8"})
+		c := color.NRGBAModel.Convert(img.At(3, 3)).(color.NRGBA)
+		assert.NotContains(t, []uint8{0x00, 0xFF}, c.R, "lanczos should blend neighbouring pixels")
+	})
+
+	t.Run("no_upscale", func(t *testing.T) {
+		img := convert(t, map[string]string{"width": "8", "no_upscale": "true"})
+		assert.Equal(t, image.Rect(0, 0, 2, 2), img.Bounds())
+
+		// exact 模式按同一比例缩小目标框，保持 8:2 的比例
+		img = convert(t, map[string]string{"width": "8", "height": "2", "no_upscale": "true"})
+		assert.Equal(t, image.Rect(0, 0, 2, 1), img.Bounds())
+		img = convert(t, map[string]string{"width": "2", "height": "1", "no_upscale": "true"})
+		assert.Equal(t, image.Rect(0, 0, 2, 1), img.Bounds())
+
+		img = convert(t, map[string]string{"width": "8", "height": "8", "resize_mode": "fill", "no_upscale": "true"})
+		assert.Equal(t, image.Rect(0, 0, 2, 2), img.Bounds())
+
+		img = convert(t, map[string]string{"width": "8", "height": "8", "resize_mode": "pad", "no_upscale": "true"})
+		assert.Equal(t, image.Rect(0, 0, 8, 8), img.Bounds())
+		assert.Equal(t, color.NRGBA{A: 0xFF}, color.NRGBAModel.Convert(img.At(3, 3)))
+		assert.Equal(t, color.NRGBA{}, color.NRGBAModel.Convert(img.At(0, 0)))
+	})
+
+	t.Run("illegal filter", func(t *testing.T) {
+		_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Tiff, pngData, map[string]string{"filter": "bicubic"})
+		require.Error(t, err)
+	})
+}
+
+func TestFlattenBackground(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// 100x100，左半边透明，右半边红色
+	src := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	draw.Draw(src, image.Rect(50, 0, 100, 100), &image.Uniform{C: color.NRGBA{R: 0xFF, A: 0xFF}}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+	pngData := buf.Bytes()
+
+	paletted := image.NewPaletted(src.Bounds(), color.Palette{color.NRGBA{}, color.NRGBA{R: 0xFF, A: 0xFF}})
+	draw.Draw(paletted, paletted.Bounds(), src, image.Point{}, draw.Src)
+	var gifBuf bytes.Buffer
+	require.NoError(t, gif.Encode(&gifBuf, paletted, nil))
+	gifData := gifBuf.Bytes()
+
+	svgData := []byte(`<svg width="100" height="100" viewBox="0 0 100 100" xmlns="http://www.w3.org/2000/svg">` +
+		`<rect x="50" y="0" width="50" height="100" fill="#ff0000" /></svg>`)
+
+	dark := color.NRGBA{R: 0x11, G: 0x11, B: 0x11, A: 0xFF}
+	white := color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	red := color.NRGBA{R: 0xFF, A: 0xFF}
+
+	sources := []struct {
+		from contract.ConceptName
+		in   []byte
+	}{
+		{contract.Png, pngData},
+		{contract.Gif, gifData},
+		{contract.Svg, svgData},
+	}
+	for _, s := range sources {
+		t.Run(string(s.from), func(t *testing.T) {
+			// 默认白色
+			out, err := ry.Convert(ctx, contract.File, s.from, contract.Jpeg, s.in, nil)
+			require.NoError(t, err)
+			img, err := jpeg.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assertColor(t, white, img.At(25, 50))
+			assertColor(t, red, img.At(75, 50))
+
+			out, err = ry.Convert(ctx, contract.File, s.from, contract.Jpeg, s.in, map[string]string{"background": "#111"})
+			require.NoError(t, err)
+			img, err = jpeg.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assertColor(t, dark, img.At(25, 50))
+			assertColor(t, red, img.At(75, 50))
+		})
+	}
+
+	// pad 模式的画布使用同一背景色
+	out, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, pngData,
+		map[string]string{"width": "200", "height": "100", "resize_mode": "pad", "background": "#111"})
+	require.NoError(t, err)
+	img, err := jpeg.Decode(bytes.NewReader(out))
+	require.NoError(t, err)
+	assertColor(t, dark, img.At(10, 50))
+	assertColor(t, red, img.At(140, 50))
+}
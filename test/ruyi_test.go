@@ -3,14 +3,23 @@ package ruyi_test
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"hash/crc32"
+	"image"
 	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/wukong-app/ruyi"
 	"github.com/wukong-app/ruyi/pkg/contract"
+	"github.com/wukong-app/ruyi/pkg/exception"
 )
 
 func TestRuyiExpandAndShrink(t *testing.T) {
@@ -89,3 +98,437 @@ func TestConvertFile(t *testing.T) {
 		t.Logf("PNG -> JPEG 转换成功，输出文件: %s", outputPath)
 	})
 }
+
+func TestRuyiConvert(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	fromData, err := os.ReadFile("testdata/shop.png")
+	require.NoError(t, err)
+
+	t.Run("CanConvert", func(t *testing.T) {
+		require.True(t, ry.CanConvert(ctx, contract.File, contract.Png, contract.Jpg))
+		require.False(t, ry.CanConvert(ctx, contract.File, contract.Png, "not_exist"))
+	})
+
+	t.Run("Convert 成功", func(t *testing.T) {
+		toData, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, fromData, map[string]string{"width": "64"})
+		require.NoError(t, err)
+
+		img, err := jpeg.Decode(bytes.NewReader(toData))
+		require.NoError(t, err)
+		require.Equal(t, 64, img.Bounds().Dx())
+	})
+
+	t.Run("Convert 找不到转换器", func(t *testing.T) {
+		_, err := ry.Convert(ctx, contract.File, contract.Png, "not_exist", fromData, nil)
+		require.ErrorIs(t, err, exception.ErrNoSupportedConverter)
+	})
+
+	t.Run("Convert 执行失败", func(t *testing.T) {
+		_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, []byte("not a png"), nil)
+		require.ErrorIs(t, err, exception.ErrConvertFailed)
+		require.ErrorIs(t, err, exception.ErrDecodeFailed)
+		require.Contains(t, err.Error(), "kind=file, from=png, to=jpeg")
+
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, fromData, map[string]string{"quality": "0"})
+		require.ErrorIs(t, err, exception.ErrConvertFailed)
+		require.ErrorIs(t, err, exception.ErrIllegalConverterParam)
+	})
+}
+
+func TestRuyiError(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	fromData, err := os.ReadFile("testdata/shop.png")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name       string
+		convert    func() error
+		code       exception.Code
+		httpStatus int
+		sentinel   error
+		param      string
+	}{
+		{
+			name: "不支持的转换",
+			convert: func() error {
+				_, err := ry.Convert(ctx, contract.File, contract.Png, "not_exist", fromData, nil)
+				return err
+			},
+			code:       exception.CodeNoConverter,
+			httpStatus: http.StatusUnsupportedMediaType,
+			sentinel:   exception.ErrNoSupportedConverter,
+		},
+		{
+			name: "参数非法",
+			convert: func() error {
+				_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, fromData, map[string]string{"quality": "0"})
+				return err
+			},
+			code:       exception.CodeIllegalParam,
+			httpStatus: http.StatusBadRequest,
+			sentinel:   exception.ErrIllegalConverterParam,
+			param:      "quality",
+		},
+		{
+			name: "解码失败",
+			convert: func() error {
+				_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, []byte("not a png"), nil)
+				return err
+			},
+			code:       exception.CodeDecodeFailed,
+			httpStatus: http.StatusUnprocessableEntity,
+			sentinel:   exception.ErrDecodeFailed,
+		},
+		{
+			name: "无法识别源格式",
+			convert: func() error {
+				_, _, err := ry.ConvertTo(ctx, contract.File, contract.Jpeg, []byte("not a png"), nil)
+				return err
+			},
+			code:       exception.CodeUnrecognizedContent,
+			httpStatus: http.StatusUnsupportedMediaType,
+			sentinel:   exception.ErrUnrecognizedContent,
+		},
+		{
+			name: "调用方取消",
+			convert: func() error {
+				canceledCtx, cancel := context.WithCancel(ctx)
+				cancel()
+				_, err := ry.Convert(canceledCtx, contract.File, contract.Bmp, contract.Gif, fromData, nil)
+				return err
+			},
+			code:       exception.CodeCanceled,
+			httpStatus: exception.StatusClientClosedRequest,
+			sentinel:   context.Canceled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.convert()
+			require.Error(t, err)
+			require.ErrorIs(t, err, c.sentinel)
+
+			var ruyiErr *exception.RuyiError
+			require.True(t, exception.As(err, &ruyiErr))
+			assert.Equal(t, c.code, ruyiErr.Code)
+			assert.Equal(t, c.code, exception.CodeOf(err))
+			assert.Equal(t, c.httpStatus, ruyiErr.HTTPStatus())
+			assert.Equal(t, "file", ruyiErr.Kind)
+			assert.Equal(t, c.param, ruyiErr.Param)
+			assert.NotEmpty(t, ruyiErr.UserMessage())
+		})
+	}
+
+	t.Run("推断错误码", func(t *testing.T) {
+		err := exception.Wrapf(exception.ErrLimitExceeded, "too many pixels")
+		assert.Equal(t, exception.CodeLimitExceeded, exception.CodeOf(err))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, exception.AsRuyiError(err).HTTPStatus())
+		assert.Equal(t, exception.CodeUnknown, exception.CodeOf(exception.Errorf("boom")))
+		assert.Equal(t, exception.Code(""), exception.CodeOf(nil))
+		assert.Nil(t, exception.AsRuyiError(nil))
+	})
+}
+
+func TestRuyiCapabilityDiscovery(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	require.Equal(t, []contract.Kind{contract.File}, ry.ListKinds())
+	require.Contains(t, ry.ListConcepts(contract.File), contract.HEIC())
+	require.Empty(t, ry.ListConcepts("not_exist"))
+
+	converters := ry.ListConverters(contract.File)
+	require.NotEmpty(t, converters)
+	for _, c := range converters {
+		require.Equal(t, contract.File, c.From().Kind())
+	}
+
+	// BMP 可经 PNG 多跳转换为 GIF、ICO
+	targets := ry.TargetsFrom(contract.File, contract.Bmp)
+	require.Equal(t, contract.PNG(), targets[0])
+	require.Contains(t, targets, contract.GIF())
+	require.Contains(t, targets, contract.ICO())
+	require.NotContains(t, targets, contract.BMP())
+
+	sources := ry.SourcesTo(contract.File, contract.Ico)
+	require.Contains(t, sources, contract.PNG())
+	require.Contains(t, sources, contract.WEBP())
+	require.NotContains(t, sources, contract.ICO())
+}
+
+func TestRuyiConvertStream(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("Converter 实现 StreamConverter", func(t *testing.T) {
+		converter, err := ry.GetConverter(ctx, contract.File, contract.Tiff, contract.Png)
+		require.NoError(t, err)
+		_, ok := converter.(contract.StreamConverter)
+		require.True(t, ok)
+	})
+
+	t.Run("直接转换", func(t *testing.T) {
+		f, err := os.Open("testdata/shop.tiff")
+		require.NoError(t, err)
+		defer f.Close()
+
+		var out bytes.Buffer
+		err = ry.ConvertStream(ctx, contract.File, contract.Tiff, contract.Jpeg, f, &out, map[string]string{"width": "100"})
+		require.NoError(t, err)
+
+		img, err := jpeg.Decode(&out)
+		require.NoError(t, err)
+		require.Equal(t, 100, img.Bounds().Dx())
+	})
+
+	t.Run("多跳转换", func(t *testing.T) {
+		f, err := os.Open("testdata/shop.bmp")
+		require.NoError(t, err)
+		defer f.Close()
+
+		var out bytes.Buffer
+		err = ry.ConvertStream(ctx, contract.File, contract.Bmp, contract.Tiff, f, &out, nil)
+		require.NoError(t, err)
+		require.NotZero(t, out.Len())
+	})
+
+	t.Run("转换失败", func(t *testing.T) {
+		var out bytes.Buffer
+		err = ry.ConvertStream(ctx, contract.File, contract.Tiff, contract.Png, bytes.NewReader([]byte("not a tiff")), &out, nil)
+		require.ErrorIs(t, err, exception.ErrConvertFailed)
+	})
+}
+
+func TestRuyiConvertTo(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// shop.heif 的内容实际是 PNG
+	fromData, err := os.ReadFile("testdata/shop.heif")
+	require.NoError(t, err)
+
+	out, from, err := ry.ConvertTo(ctx, contract.File, contract.Jpeg, fromData, nil)
+	require.NoError(t, err)
+	require.Equal(t, contract.PNG(), from)
+	_, err = jpeg.Decode(bytes.NewReader(out))
+	require.NoError(t, err)
+
+	_, _, err = ry.ConvertTo(ctx, contract.File, contract.Jpeg, []byte("hello world"), nil)
+	require.ErrorIs(t, err, exception.ErrUnrecognizedContent)
+}
+
+func TestRuyiSameFormat(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	files := map[contract.ConceptName]string{
+		contract.Png:  "testdata/shop.png",
+		contract.Jpeg: "testdata/shop.jpg",
+		contract.Gif:  "testdata/shop.gif",
+		contract.Bmp:  "testdata/shop.bmp",
+		contract.Tiff: "testdata/shop.tiff",
+		contract.Ico:  "testdata/shop.ico",
+	}
+	for name, file := range files {
+		t.Run(string(name), func(t *testing.T) {
+			converter, err := ry.GetConverter(ctx, contract.File, name, name)
+			require.NoError(t, err)
+			require.Equal(t, name, converter.To().Name())
+
+			fromData, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			out, err := ry.Convert(ctx, contract.File, name, name, fromData, map[string]string{"width": "64"})
+			require.NoError(t, err)
+
+			concept, ok := contract.DetectConcept(out)
+			require.True(t, ok)
+			require.Equal(t, name, concept.Name())
+
+			config, _, err := image.DecodeConfig(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, 64, config.Width)
+			assert.Equal(t, 64, config.Height)
+		})
+	}
+
+	// 缩小并降低质量，结果应更小
+	fromData, err := os.ReadFile("testdata/shop.jpg")
+	require.NoError(t, err)
+	out, err := ry.Convert(ctx, contract.File, contract.Jpeg, contract.Jpeg, fromData, map[string]string{"width": "100", "quality": "60"})
+	require.NoError(t, err)
+	assert.Less(t, len(out), len(fromData))
+}
+
+func TestRuyiContextCancel(t *testing.T) {
+	ry, err := ruyi.New()
+	require.NoError(t, err)
+
+	fromData, err := os.ReadFile("testdata/shop.png")
+	require.NoError(t, err)
+
+	t.Run("已取消的 ctx", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, fromData, nil)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, exception.CodeCanceled, exception.CodeOf(err))
+
+		svgData, err := os.ReadFile("testdata/shop.svg")
+		require.NoError(t, err)
+		_, err = ry.Convert(ctx, contract.File, contract.Svg, contract.Png, svgData, nil)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("超时立即返回", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// 输入端一直阻塞，模拟耗时的解码阶段
+		r, pw := io.Pipe()
+		defer pw.Close()
+
+		start := time.Now()
+		err := ry.ConvertStream(ctx, contract.File, contract.Png, contract.Jpeg, r, io.Discard, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, exception.CodeDeadlineExceeded, exception.CodeOf(err))
+		assert.Less(t, time.Since(start), 2*time.Second)
+	})
+
+	t.Run("缩放、模糊等计算阶段响应取消", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		// 完整执行需要数秒：计算分带执行，超时后不再继续计算，Convert 返回时已无计算在进行
+		start := time.Now()
+		_, err := ry.Convert(ctx, contract.File, contract.Png, contract.Png, fromData, map[string]string{
+			"width": "3000", "height": "3000", "blur": "20", "sharpen": "5",
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 2*time.Second)
+	})
+}
+
+func TestRuyiLimits(t *testing.T) {
+	ctx := context.Background()
+
+	pngData, err := os.ReadFile("testdata/shop.png")
+	require.NoError(t, err)
+	svgData, err := os.ReadFile("testdata/shop.svg")
+	require.NoError(t, err)
+
+	requireLimitExceeded := func(t *testing.T, err error) {
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+		assert.Equal(t, exception.CodeLimitExceeded, exception.CodeOf(err))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, exception.AsRuyiError(err).HTTPStatus())
+	}
+
+	t.Run("默认限制下正常转换", func(t *testing.T) {
+		ry, err := ruyi.New()
+		require.NoError(t, err)
+
+		for _, name := range []string{"shop.jpg", "shop.gif", "shop.bmp", "shop.tiff", "shop.webp", "shop.ico", "shop.svg"} {
+			data, err := os.ReadFile("testdata/" + name)
+			require.NoError(t, err)
+			_, _, err = ry.ConvertTo(ctx, contract.File, contract.Png, data, nil)
+			require.NoError(t, err, name)
+		}
+	})
+
+	t.Run("解压炸弹", func(t *testing.T) {
+		ry, err := ruyi.New()
+		require.NoError(t, err)
+
+		// 1x1 的 PNG，图片头声明为 60000x60000
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 1, 1))))
+		bomb := buf.Bytes()
+		binary.BigEndian.PutUint32(bomb[16:20], 60000)
+		binary.BigEndian.PutUint32(bomb[20:24], 60000)
+		binary.BigEndian.PutUint32(bomb[29:33], crc32.ChecksumIEEE(bomb[12:29]))
+
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, bomb, nil)
+		requireLimitExceeded(t, err)
+
+		err = ry.ConvertStream(ctx, contract.File, contract.Png, contract.Jpeg, bytes.NewReader(bomb), io.Discard, nil)
+		requireLimitExceeded(t, err)
+	})
+
+	t.Run("输入字节数", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithLimits(contract.Limits{MaxInputBytes: 100}))
+		require.NoError(t, err)
+
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, pngData, nil)
+		requireLimitExceeded(t, err)
+
+		err = ry.ConvertStream(ctx, contract.File, contract.Png, contract.Jpeg, bytes.NewReader(pngData), io.Discard, nil)
+		requireLimitExceeded(t, err)
+
+		// context 覆盖实例配置
+		_, err = ry.Convert(contract.ContextWithLimits(ctx, contract.Limits{}), contract.File, contract.Png, contract.Jpeg, pngData, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("GetConverter 取得的转换器", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithLimits(contract.Limits{MaxPixels: 100}))
+		require.NoError(t, err)
+
+		conv, err := ry.GetConverter(ctx, contract.File, contract.Png, contract.Jpeg)
+		require.NoError(t, err)
+		_, err = conv.Convert(ctx, pngData, nil)
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+
+		stream, ok := conv.(contract.StreamConverter)
+		require.True(t, ok)
+		err = stream.ConvertStream(ctx, bytes.NewReader(pngData), io.Discard, nil)
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+
+		// ListConverters 取得的转换器同样遵循实例配置
+		for _, c := range ry.ListConverters(contract.File) {
+			if c.From().Name() == contract.Png && c.To().Name() == contract.Png {
+				_, err = c.Convert(ctx, pngData, nil)
+				require.ErrorIs(t, err, exception.ErrLimitExceeded)
+			}
+		}
+
+		// 多跳转换器
+		conv, err = ry.GetConverter(ctx, contract.File, contract.Png, contract.Tiff)
+		require.NoError(t, err)
+		_, err = conv.Convert(ctx, pngData, nil)
+		require.ErrorIs(t, err, exception.ErrLimitExceeded)
+	})
+
+	t.Run("输出尺寸", func(t *testing.T) {
+		ry, err := ruyi.New()
+		require.NoError(t, err)
+
+		_, err = ry.Convert(ctx, contract.File, contract.Png, contract.Jpeg, pngData, map[string]string{"width": "20000"})
+		requireLimitExceeded(t, err)
+
+		_, err = ry.Convert(ctx, contract.File, contract.Svg, contract.Png, svgData, map[string]string{"height": "20000"})
+		requireLimitExceeded(t, err)
+	})
+
+	t.Run("SVG 元素个数", func(t *testing.T) {
+		ry, err := ruyi.New(ruyi.WithLimits(contract.Limits{MaxSVGElements: 2}))
+		require.NoError(t, err)
+
+		_, err = ry.Convert(ctx, contract.File, contract.Svg, contract.Png, svgData, nil)
+		requireLimitExceeded(t, err)
+	})
+}